@@ -13,6 +13,7 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 
 	"github.com/user/reviewer-svc/internal/infrastructure/db/postgres"
+	"github.com/user/reviewer-svc/internal/infrastructure/db/sqlite"
 	"github.com/user/reviewer-svc/internal/app"
 	"github.com/user/reviewer-svc/internal/app/config"
 	"github.com/user/reviewer-svc/internal/infrastructure/logger"
@@ -29,27 +30,79 @@ func main() {
 
 	lg := logger.New(cfg.LogLevel)
 
-	if err := postgres.Migrate(cfg.DBDSN, "migrations"); err != nil {
-		lg.Error("migrations failed", "err", err)
+	shutdownTracing, err := app.SetupTracing(ctx, cfg)
+	if err != nil {
+		lg.Error("tracing setup failed", "err", err)
 		os.Exit(1)
 	}
+	defer func() {
+		flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(flushCtx); err != nil {
+			lg.Error("tracing shutdown error", "err", err)
+		}
+	}()
+
+	var store app.Storage
+	switch cfg.Storage {
+	case config.StorageMemory:
+		lg.Warn("using in-memory storage, data is lost on restart")
+		store = app.NewMemoryStorage()
+	case config.StorageSQLite:
+		db, err := sqlite.Open(cfg.DBDSN)
+		if err != nil {
+			lg.Error("db open failed", "err", err)
+			os.Exit(1)
+		}
+		defer db.Close()
 
-	dbCtx, cancelDB := context.WithTimeout(ctx, 10*time.Second)
-	defer cancelDB()
+		if err := sqlite.Migrate(ctx, db, "migrations/sqlite"); err != nil {
+			lg.Error("migrations failed", "err", err)
+			os.Exit(1)
+		}
 
-	pool, err := postgres.NewPool(dbCtx, cfg.DBDSN)
-	if err != nil {
-		lg.Error("db connect failed", "err", err)
-		os.Exit(1)
+		store = app.NewSQLiteStorage(db)
+	default:
+		if err := postgres.Migrate(cfg.DBDSN, "migrations"); err != nil {
+			lg.Error("migrations failed", "err", err)
+			os.Exit(1)
+		}
+
+		dbCtx, cancelDB := context.WithTimeout(ctx, 10*time.Second)
+		defer cancelDB()
+
+		pool, err := postgres.NewPool(dbCtx, cfg.DBDSN)
+		if err != nil {
+			lg.Error("db connect failed", "err", err)
+			os.Exit(1)
+		}
+		defer pool.Close()
+
+		store = app.NewPostgresStorage(pool)
+		if cfg.DBReplicaDSN != "" {
+			replica, err := postgres.NewPool(dbCtx, cfg.DBReplicaDSN)
+			if err != nil {
+				lg.Error("replica connect failed", "err", err)
+				os.Exit(1)
+			}
+			defer replica.Close()
+
+			store = app.NewReplicatedPostgresStorage(pool, replica)
+		}
 	}
-	defer pool.Close()
 
 	r := chi.NewRouter()
 	r.Use(middleware.RequestID)
 	r.Use(middleware.Recoverer)
 	r.Use(middleware.Timeout(30 * time.Second))
 
-	handler := app.NewHandler(r, pool, lg)
+	svc := app.NewServices(store, cfg)
+	handler := app.NewHandler(r, store, svc, cfg, lg)
+
+	dispatcher := app.NewWebhookDispatcher(store, cfg, lg)
+	go dispatcher.Run(ctx)
+	go app.NewAbsenceWorker(svc.Absences, cfg, lg).Run(ctx)
+	go app.NewIdempotencyPurger(svc.Idempotency, lg).Run(ctx)
 
 	srv := &http.Server{
 		Addr:    ":" + cfg.Port,
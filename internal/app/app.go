@@ -1,53 +1,196 @@
 package app
 
 import (
+	"context"
 	"net/http"
 
 	"log/slog"
 
 	chi "github.com/go-chi/chi/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 
+	"github.com/user/reviewer-svc/internal/app/config"
 	handler "github.com/user/reviewer-svc/internal/app/handler"
+	"github.com/user/reviewer-svc/internal/app/handler/auth"
+	apikeysvc "github.com/user/reviewer-svc/internal/domain/apikey"
+	codeownerssvc "github.com/user/reviewer-svc/internal/domain/codeowners"
+	idempotencysvc "github.com/user/reviewer-svc/internal/domain/idempotency"
+	integrationsvc "github.com/user/reviewer-svc/internal/domain/integration"
 	prsvc "github.com/user/reviewer-svc/internal/domain/pr"
 	statssvc "github.com/user/reviewer-svc/internal/domain/stats"
 	teamsvc "github.com/user/reviewer-svc/internal/domain/team"
 	usersvc "github.com/user/reviewer-svc/internal/domain/user"
 	userreassign "github.com/user/reviewer-svc/internal/domain/userreassign"
+	webhooksvc "github.com/user/reviewer-svc/internal/domain/webhook"
 	"github.com/user/reviewer-svc/internal/infrastructure/clock"
-	postgres "github.com/user/reviewer-svc/internal/infrastructure/db/postgres"
 	"github.com/user/reviewer-svc/internal/infrastructure/idgen"
+	"github.com/user/reviewer-svc/internal/infrastructure/metrics"
+	"github.com/user/reviewer-svc/internal/infrastructure/oidc"
 	"github.com/user/reviewer-svc/internal/infrastructure/random"
+	"github.com/user/reviewer-svc/internal/infrastructure/tracing"
+	webhookinfra "github.com/user/reviewer-svc/internal/infrastructure/webhook"
 )
 
-func NewHandler(r chi.Router, pool *pgxpool.Pool, log *slog.Logger) http.Handler {
-	txManager := postgres.NewTxManager(pool)
-	teamRepo := postgres.NewTeamRepo()
-	userRepo := postgres.NewUserRepo()
-	prRepo := postgres.NewPRRepo()
+// Services is the domain layer shared by the HTTP handler and the background
+// workers. Build it once with NewServices.
+type Services struct {
+	Teams        *teamsvc.TeamService
+	Codeowners   *codeownerssvc.Service
+	Users        *usersvc.UserService
+	UserBulk     *usersvc.UserBulkService
+	Absences     *usersvc.AbsenceService
+	Tags         *usersvc.TagService
+	PRs          *prsvc.PRService
+	Stats        *statssvc.StatsService
+	Webhooks     *webhooksvc.WebhookService
+	Integrations *integrationsvc.Service
+	APIKeys      *apikeysvc.Service
+	Idempotency  *idempotencysvc.Service
 
+	metrics *metrics.Metrics
+}
+
+func NewServices(store Storage, cfg config.Config) Services {
+	m := metrics.New()
+	for name, pool := range store.pools {
+		m.RegisterPool(name, pool)
+	}
 	clk := clock.SystemClock{}
 	rnd := random.New()
 	idGen := idgen.NewUUIDGenerator()
-	strategy := usersvc.NewRandomAssignmentStrategy(rnd)
+	strategy := newAssignmentStrategy(cfg.AssignmentStrategy, store, rnd)
 
-	teamSvc := teamsvc.NewTeamService(teamRepo, txManager, clk, idGen)
+	userReassignSvc := userreassign.NewUserReassignmentService(store.prs, store.users, store.teams, store.history, store.webhooks, clk, strategy, store.prs)
+	prSvc := prsvc.NewPRService(store.prs, store.users, store.teams, store.codeowners, store.history, store.webhooks, store.tx, clk, idGen, strategy, store.prs, cfg.MergeRequiredApprovals, m)
 
-	userReassignSvc := userreassign.NewUserReassignmentService(prRepo, userRepo, clk, strategy)
-	userSvc := usersvc.NewUserService(userRepo, teamRepo, txManager, clk, idGen, userReassignSvc)
-	userBulkSvc := usersvc.NewUserBulkService(userRepo, teamRepo, txManager, userReassignSvc)
-	prSvc := prsvc.NewPRService(prRepo, userRepo, txManager, clk, idGen, strategy)
-	statsSvc := statssvc.NewStatsService(prRepo, txManager)
+	return Services{
+		Teams:        teamsvc.NewTeamService(store.teams, store.tx, clk, idGen),
+		Codeowners:   codeownerssvc.NewService(store.codeowners, store.users, store.tx, clk),
+		Users:        usersvc.NewUserService(store.users, store.teams, store.tx, clk, idGen, userReassignSvc, store.webhooks),
+		UserBulk:     usersvc.NewUserBulkService(store.users, store.teams, store.tx, userReassignSvc, store.webhooks, clk, m),
+		Absences:     usersvc.NewAbsenceService(store.absences, store.users, store.tx, clk, idGen, userReassignSvc),
+		Tags:         usersvc.NewTagService(store.tags, store.users, store.tx),
+		PRs:          prSvc,
+		Stats:        statssvc.NewStatsService(store.prs, store.tx),
+		Webhooks:     webhooksvc.NewWebhookService(store.webhooks, store.tx, clk, idGen),
+		Integrations: integrationsvc.NewService(store.mappings, prSvc, store.tx, clk),
+		APIKeys:      apikeysvc.NewService(store.apiKeys, store.tx, clk, idGen, cfg.BootstrapAPIKey),
+		Idempotency:  idempotencysvc.NewService(store.idemKeys, store.tx, clk, cfg.IdempotencyTTL),
+
+		metrics: m,
+	}
+}
+
+func NewHandler(r chi.Router, store Storage, svc Services, cfg config.Config, log *slog.Logger) http.Handler {
+	r.Use(tracing.Middleware)
 
 	deps := handler.Deps{
-		Teams:    teamSvc,
-		Users:    userSvc,
-		UserBulk: userBulkSvc,
-		PRs:      prSvc,
-		Stats:    statsSvc,
+		Teams:    svc.Teams,
+		Users:    svc.Users,
+		UserBulk: svc.UserBulk,
+		Absences: svc.Absences,
+		PRs:      svc.PRs,
+		Stats:    svc.Stats,
+		Webhooks: svc.Webhooks,
+		GitHub:   svc.Integrations,
+		GitLab:   svc.Integrations,
+		APIKeys:  svc.APIKeys,
 		Log:      log,
-		DB:       pool,
+		DB:       store.ping,
+		Replica:  store.replica,
+		Metrics:  svc.metrics,
+		Tokens:   newTokenVerifier(cfg),
+
+		Idempotency: svc.Idempotency,
+		Codeowners:  svc.Codeowners,
+		Tags:        svc.Tags,
+		Config: handler.Config{
+			GitHubWebhookSecret:  cfg.GitHubWebhookSecret,
+			GitLabWebhookToken:   cfg.GitLabWebhookToken,
+			AccessLogSampleReads: cfg.AccessLogSampleReads,
+			AuthRequired:         cfg.AuthRequired,
+		},
 	}
 
 	return handler.NewRouter(r, deps)
 }
+
+// newTokenVerifier returns nil unless a JWKS is configured, which leaves SSO
+// bearer tokens disabled.
+func newTokenVerifier(cfg config.Config) auth.TokenVerifier {
+	var keys *oidc.KeySet
+	switch {
+	case cfg.JWTJWKSFile != "":
+		keys = oidc.NewFileKeySet(cfg.JWTJWKSFile, cfg.JWTJWKSCacheTTL)
+	case cfg.JWTJWKSURL != "":
+		keys = oidc.NewURLKeySet(cfg.JWTJWKSURL, cfg.JWTJWKSCacheTTL)
+	default:
+		return nil
+	}
+	return oidc.NewVerifier(keys, oidc.Config{
+		Issuer:     cfg.JWTIssuer,
+		Audience:   cfg.JWTAudience,
+		UserClaim:  cfg.JWTUserClaim,
+		RolesClaim: cfg.JWTRolesClaim,
+	})
+}
+
+// SetupTracing installs the exporter chosen in cfg; the returned function
+// flushes spans on shutdown.
+func SetupTracing(ctx context.Context, cfg config.Config) (func(context.Context) error, error) {
+	return tracing.Setup(ctx, tracing.Config{
+		Exporter:    cfg.TraceExporter,
+		File:        cfg.TraceFile,
+		SampleRatio: cfg.TraceSampleRatio,
+	})
+}
+
+// newAssignmentStrategy builds the configured strategy, wrapped in the
+// tag-aware one, which only steps in for PRs that require tags.
+func newAssignmentStrategy(name string, store Storage, rnd *random.Rand) usersvc.AssignmentStrategy {
+	var base usersvc.AssignmentStrategy
+	switch name {
+	case config.StrategyLeastLoaded:
+		base = usersvc.NewLeastLoadedAssignmentStrategy(store.prs, rnd)
+	case config.StrategyRoundRobin:
+		base = usersvc.NewRoundRobinAssignmentStrategy(store.rotation)
+	default:
+		base = usersvc.NewRandomAssignmentStrategy(rnd)
+	}
+	return usersvc.NewTagAwareAssignmentStrategy(base, store.tags)
+}
+
+func NewWebhookDispatcher(store Storage, cfg config.Config, log *slog.Logger) *webhookinfra.Dispatcher {
+	return webhookinfra.NewDispatcher(store.webhooks, store.tx, clock.SystemClock{}, webhookinfra.Config{
+		PollInterval: cfg.WebhookPollInterval,
+		BatchSize:    cfg.WebhookBatchSize,
+		MaxAttempts:  cfg.WebhookMaxAttempts,
+		BackoffBase:  cfg.WebhookBackoffBase,
+		BackoffMax:   cfg.WebhookBackoffMax,
+		Timeout:      cfg.WebhookTimeout,
+	}, log)
+}
+
+// NewIdempotencyPurger periodically deletes stored responses to idempotent
+// requests once their TTL has passed.
+func NewIdempotencyPurger(idempotency *idempotencysvc.Service, log *slog.Logger) *Worker {
+	return NewWorker("idempotency purge", idempotencyPurgeInterval, func(ctx context.Context) error {
+		for {
+			n, err := idempotency.PurgeExpired(ctx, idempotencyPurgeBatchSize)
+			if err != nil || n < idempotencyPurgeBatchSize {
+				return err
+			}
+		}
+	}, log)
+}
+
+// NewAbsenceWorker periodically hands over the open reviews of users whose
+// absence has just started.
+func NewAbsenceWorker(absences *usersvc.AbsenceService, cfg config.Config, log *slog.Logger) *Worker {
+	return NewWorker("absence reassignment", cfg.AbsencePollInterval, func(ctx context.Context) error {
+		n, err := absences.ReassignStartedAbsences(ctx, absenceBatchSize)
+		if n > 0 {
+			log.Info("reassigned reviews of absent users", "absences", n)
+		}
+		return err
+	}, log)
+}
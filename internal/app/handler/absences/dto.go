@@ -0,0 +1,36 @@
+package absences
+
+import "time"
+
+type Absence struct {
+	AbsenceID       string  `json:"absence_id"`
+	UserID          string  `json:"user_id"`
+	From            string  `json:"from"`
+	To              string  `json:"to"`
+	Reason          string  `json:"reason,omitempty"`
+	ReassignOpenPRs bool    `json:"reassign_open_prs"`
+	ReassignedAt    *string `json:"reassigned_at,omitempty"`
+}
+
+type CreateAbsenceRequest struct {
+	UserID          string    `json:"user_id"`
+	From            time.Time `json:"from"`
+	To              time.Time `json:"to"`
+	Reason          string    `json:"reason,omitempty"`
+	ReassignOpenPRs bool      `json:"reassign_open_prs"`
+}
+
+type UpdateAbsenceRequest struct {
+	From            time.Time `json:"from"`
+	To              time.Time `json:"to"`
+	Reason          string    `json:"reason,omitempty"`
+	ReassignOpenPRs bool      `json:"reassign_open_prs"`
+}
+
+type AbsenceResponse struct {
+	Absence Absence `json:"absence"`
+}
+
+type ListAbsencesResponse struct {
+	Items []Absence `json:"items"`
+}
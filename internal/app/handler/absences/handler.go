@@ -0,0 +1,121 @@
+package absences
+
+import (
+	"net/http"
+
+	chi "github.com/go-chi/chi/v5"
+
+	"github.com/user/reviewer-svc/internal/app/httpserver"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// @Summary     Schedule user absence
+// @Tags        users
+// @Accept      json
+// @Produce     json
+// @Param       body  body      CreateAbsenceRequest  true  "Absence period"
+// @Success     201   {object}  AbsenceResponse
+// @Failure     400   {object}  httpserver.ErrorResponse
+// @Failure     404   {object}  httpserver.ErrorResponse
+// @Router      /users/absences [post]
+func (h *Handler) CreateAbsence(w http.ResponseWriter, r *http.Request) {
+	var req CreateAbsenceRequest
+	if err := httpserver.DecodeJSON(r, &req); err != nil {
+		httpserver.Logger(r).Error("create absence: invalid JSON", "err", err)
+		httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid JSON", nil)
+		return
+	}
+	if req.UserID == "" {
+		httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "user_id is required", nil)
+		return
+	}
+
+	a, err := h.service.CreateAbsence(r.Context(), req.UserID, req.From, req.To, req.Reason, req.ReassignOpenPRs)
+	if err != nil {
+		httpserver.WriteDomainError(w, r, "create absence failed", err)
+		return
+	}
+
+	httpserver.WriteJSON(w, http.StatusCreated, AbsenceResponse{Absence: toResponse(*a)})
+}
+
+// @Summary     List user absences
+// @Tags        users
+// @Produce     json
+// @Param       user_id  query     string  true  "User ID"
+// @Success     200      {object}  ListAbsencesResponse
+// @Failure     400      {object}  httpserver.ErrorResponse
+// @Failure     404      {object}  httpserver.ErrorResponse
+// @Router      /users/absences [get]
+func (h *Handler) ListAbsences(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "user_id is required", nil)
+		return
+	}
+
+	list, err := h.service.ListAbsences(r.Context(), userID)
+	if err != nil {
+		httpserver.WriteDomainError(w, r, "list absences failed", err)
+		return
+	}
+
+	res := ListAbsencesResponse{Items: make([]Absence, 0, len(list))}
+	for _, a := range list {
+		res.Items = append(res.Items, toResponse(a))
+	}
+	httpserver.WriteJSON(w, http.StatusOK, res)
+}
+
+// @Summary     Change user absence
+// @Tags        users
+// @Accept      json
+// @Produce     json
+// @Param       absenceId  path      string                true  "Absence ID"
+// @Param       body       body      UpdateAbsenceRequest  true  "Absence period"
+// @Success     200        {object}  AbsenceResponse
+// @Failure     400        {object}  httpserver.ErrorResponse
+// @Failure     404        {object}  httpserver.ErrorResponse
+// @Router      /users/absences/{absenceId} [put]
+func (h *Handler) UpdateAbsence(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "absenceId")
+
+	var req UpdateAbsenceRequest
+	if err := httpserver.DecodeJSON(r, &req); err != nil {
+		httpserver.Logger(r).Error("update absence: invalid JSON", "err", err)
+		httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid JSON", nil)
+		return
+	}
+
+	a, err := h.service.UpdateAbsence(r.Context(), id, req.From, req.To, req.Reason, req.ReassignOpenPRs)
+	if err != nil {
+		httpserver.WriteDomainError(w, r, "update absence failed", err)
+		return
+	}
+
+	httpserver.WriteJSON(w, http.StatusOK, AbsenceResponse{Absence: toResponse(*a)})
+}
+
+// @Summary     Cancel user absence
+// @Tags        users
+// @Param       absenceId  path  string  true  "Absence ID"
+// @Success     204
+// @Failure     404  {object}  httpserver.ErrorResponse
+// @Router      /users/absences/{absenceId} [delete]
+func (h *Handler) DeleteAbsence(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "absenceId")
+
+	if err := h.service.DeleteAbsence(r.Context(), id); err != nil {
+		httpserver.WriteDomainError(w, r, "delete absence failed", err)
+		return
+	}
+
+	httpserver.WriteNoContent(w)
+}
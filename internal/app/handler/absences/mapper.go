@@ -0,0 +1,20 @@
+package absences
+
+import domainuser "github.com/user/reviewer-svc/internal/domain/user"
+
+func toResponse(a domainuser.Absence) Absence {
+	var reassignedAt *string
+	if a.ReassignedAt != nil {
+		s := a.ReassignedAt.Format("2006-01-02T15:04:05Z07:00")
+		reassignedAt = &s
+	}
+	return Absence{
+		AbsenceID:       a.ID,
+		UserID:          a.UserID,
+		From:            a.StartsAt.Format("2006-01-02T15:04:05Z07:00"),
+		To:              a.EndsAt.Format("2006-01-02T15:04:05Z07:00"),
+		Reason:          a.Reason,
+		ReassignOpenPRs: a.ReassignOpenPRs,
+		ReassignedAt:    reassignedAt,
+	}
+}
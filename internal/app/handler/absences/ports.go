@@ -0,0 +1,15 @@
+package absences
+
+import (
+	"context"
+	"time"
+
+	domainuser "github.com/user/reviewer-svc/internal/domain/user"
+)
+
+type Service interface {
+	CreateAbsence(ctx context.Context, userID string, startsAt, endsAt time.Time, reason string, reassignOpenPRs bool) (*domainuser.Absence, error)
+	UpdateAbsence(ctx context.Context, id string, startsAt, endsAt time.Time, reason string, reassignOpenPRs bool) (*domainuser.Absence, error)
+	DeleteAbsence(ctx context.Context, id string) error
+	ListAbsences(ctx context.Context, userID string) ([]domainuser.Absence, error)
+}
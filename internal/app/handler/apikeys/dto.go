@@ -0,0 +1,25 @@
+package apikeys
+
+type APIKey struct {
+	ID        string   `json:"key_id"`
+	Name      string   `json:"name"`
+	Prefix    string   `json:"prefix"`
+	Scopes    []string `json:"scopes"`
+	CreatedAt string   `json:"created_at"`
+	RevokedAt *string  `json:"revoked_at,omitempty"`
+}
+
+type CreateKeyRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+// CreateKeyResponse is the only place the secret is ever returned.
+type CreateKeyResponse struct {
+	Key    APIKey `json:"key"`
+	Secret string `json:"secret"`
+}
+
+type ListKeysResponse struct {
+	Items []APIKey `json:"items"`
+}
@@ -0,0 +1,89 @@
+package apikeys
+
+import (
+	"errors"
+	"net/http"
+
+	chi "github.com/go-chi/chi/v5"
+
+	"github.com/user/reviewer-svc/internal/app/httpserver"
+	"github.com/user/reviewer-svc/internal/domain"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// @Summary     Create API key
+// @Description The secret is returned only in this response; the service keeps just its hash.
+// @Tags        apiKeys
+// @Accept      json
+// @Produce     json
+// @Param       body  body      CreateKeyRequest  true  "Key name and scopes"
+// @Success     201   {object}  CreateKeyResponse
+// @Failure     400   {object}  httpserver.ErrorResponse
+// @Failure     409   {object}  httpserver.ErrorResponse
+// @Router      /apiKeys [post]
+func (h *Handler) CreateKey(w http.ResponseWriter, r *http.Request) {
+	var req CreateKeyRequest
+	if err := httpserver.DecodeJSON(r, &req); err != nil {
+		httpserver.Logger(r).Error("create api key: invalid JSON", "err", err)
+		httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid JSON", nil)
+		return
+	}
+
+	key, secret, err := h.service.CreateKey(r.Context(), req.Name, toScopes(req.Scopes))
+	if err != nil {
+		if errors.Is(err, domain.ErrAlreadyExists) {
+			httpserver.Logger(r).Warn("create api key failed", "err", err, "code", "KEY_EXISTS")
+			httpserver.WriteError(w, http.StatusConflict, "KEY_EXISTS", "name already exists", nil)
+			return
+		}
+		httpserver.WriteDomainError(w, r, "create api key failed", err)
+		return
+	}
+
+	httpserver.Logger(r).Info("api key created", "key_id", key.ID, "name", key.Name)
+	httpserver.WriteJSON(w, http.StatusCreated, CreateKeyResponse{Key: toResponse(*key), Secret: secret})
+}
+
+// @Summary     List API keys
+// @Tags        apiKeys
+// @Produce     json
+// @Success     200  {object}  ListKeysResponse
+// @Router      /apiKeys [get]
+func (h *Handler) ListKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.service.ListKeys(r.Context())
+	if err != nil {
+		httpserver.WriteDomainError(w, r, "list api keys failed", err)
+		return
+	}
+
+	res := ListKeysResponse{Items: make([]APIKey, 0, len(keys))}
+	for _, k := range keys {
+		res.Items = append(res.Items, toResponse(k))
+	}
+	httpserver.WriteJSON(w, http.StatusOK, res)
+}
+
+// @Summary     Revoke API key
+// @Tags        apiKeys
+// @Param       keyId  path  string  true  "Key ID"
+// @Success     204
+// @Failure     404  {object}  httpserver.ErrorResponse
+// @Router      /apiKeys/{keyId} [delete]
+func (h *Handler) RevokeKey(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "keyId")
+
+	if err := h.service.RevokeKey(r.Context(), id); err != nil {
+		httpserver.WriteDomainError(w, r, "revoke api key failed", err)
+		return
+	}
+
+	httpserver.Logger(r).Info("api key revoked", "key_id", id)
+	httpserver.WriteNoContent(w)
+}
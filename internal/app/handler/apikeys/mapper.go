@@ -0,0 +1,32 @@
+package apikeys
+
+import (
+	domainapikey "github.com/user/reviewer-svc/internal/domain/apikey"
+)
+
+func toResponse(k domainapikey.APIKey) APIKey {
+	scopes := make([]string, 0, len(k.Scopes))
+	for _, s := range k.Scopes {
+		scopes = append(scopes, string(s))
+	}
+	res := APIKey{
+		ID:        k.ID,
+		Name:      k.Name,
+		Prefix:    k.Prefix,
+		Scopes:    scopes,
+		CreatedAt: k.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if k.RevokedAt != nil {
+		revokedAt := k.RevokedAt.Format("2006-01-02T15:04:05Z07:00")
+		res.RevokedAt = &revokedAt
+	}
+	return res
+}
+
+func toScopes(scopes []string) []domainapikey.Scope {
+	res := make([]domainapikey.Scope, 0, len(scopes))
+	for _, s := range scopes {
+		res = append(res, domainapikey.Scope(s))
+	}
+	return res
+}
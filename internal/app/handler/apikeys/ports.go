@@ -0,0 +1,13 @@
+package apikeys
+
+import (
+	"context"
+
+	domainapikey "github.com/user/reviewer-svc/internal/domain/apikey"
+)
+
+type Service interface {
+	CreateKey(ctx context.Context, name string, scopes []domainapikey.Scope) (*domainapikey.APIKey, string, error)
+	ListKeys(ctx context.Context) ([]domainapikey.APIKey, error)
+	RevokeKey(ctx context.Context, id string) error
+}
@@ -0,0 +1,25 @@
+package codeowners
+
+import "time"
+
+// SetCodeownersRequest replaces the CODEOWNERS document of team_name; an
+// empty document removes every rule.
+type SetCodeownersRequest struct {
+	TeamName   string `json:"team_name"`
+	Codeowners string `json:"codeowners"`
+}
+
+type CodeownersResponse struct {
+	TeamName   string    `json:"team_name"`
+	Codeowners string    `json:"codeowners"`
+	Rules      []Rule    `json:"rules"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type Rule struct {
+	Line     int      `json:"line"`
+	Pattern  string   `json:"pattern"`
+	Owners   []string `json:"owners"`
+	Section  string   `json:"section,omitempty"`
+	Optional bool     `json:"optional,omitempty"`
+}
@@ -0,0 +1,96 @@
+package codeowners
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/user/reviewer-svc/internal/app/httpserver"
+	"github.com/user/reviewer-svc/internal/domain"
+)
+
+type Handler struct {
+	service Service
+	teams   TeamService
+}
+
+func NewHandler(service Service, teams TeamService) *Handler {
+	return &Handler{service: service, teams: teams}
+}
+
+// @Summary     Upload team CODEOWNERS rules
+// @Tags        teams
+// @Accept      json
+// @Produce     json
+// @Param       body  body      SetCodeownersRequest  true  "CODEOWNERS document"
+// @Success     200   {object}  CodeownersResponse
+// @Failure     400   {object}  httpserver.ErrorResponse
+// @Failure     404   {object}  httpserver.ErrorResponse
+// @Router      /team/setCodeowners [post]
+func (h *Handler) SetCodeowners(w http.ResponseWriter, r *http.Request) {
+	var req SetCodeownersRequest
+	if err := httpserver.DecodeJSON(r, &req); err != nil {
+		httpserver.Logger(r).Error("set codeowners: invalid JSON", "err", err)
+		httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid JSON", nil)
+		return
+	}
+	if req.TeamName == "" {
+		httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "team_name is required", nil)
+		return
+	}
+
+	team, err := h.teams.GetTeamByName(r.Context(), req.TeamName)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			httpserver.WriteError(w, http.StatusNotFound, "NOT_FOUND", "team not found", nil)
+			return
+		}
+		httpserver.WriteDomainError(w, r, "set codeowners: get team failed", err)
+		return
+	}
+
+	rs, doc, err := h.service.SetRules(r.Context(), team.ID, req.Codeowners)
+	if err != nil {
+		httpserver.WriteDomainError(w, r, "set codeowners failed", err)
+		return
+	}
+
+	httpserver.WriteJSON(w, http.StatusOK, toResponse(team.Name, *rs, doc))
+}
+
+// @Summary     Get team CODEOWNERS rules
+// @Tags        teams
+// @Produce     json
+// @Param       team_name  query     string  true  "Team name"
+// @Success     200        {object}  CodeownersResponse
+// @Failure     400        {object}  httpserver.ErrorResponse
+// @Failure     404        {object}  httpserver.ErrorResponse
+// @Router      /team/codeowners [get]
+func (h *Handler) GetCodeowners(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "team_name is required", nil)
+		return
+	}
+
+	team, err := h.teams.GetTeamByName(r.Context(), teamName)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			httpserver.WriteError(w, http.StatusNotFound, "NOT_FOUND", "team not found", nil)
+			return
+		}
+		httpserver.WriteDomainError(w, r, "get codeowners: get team failed", err)
+		return
+	}
+
+	rs, doc, err := h.service.GetRules(r.Context(), team.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			httpserver.WriteError(w, http.StatusNotFound, "NOT_FOUND", "team has no CODEOWNERS rules", nil)
+			return
+		}
+		httpserver.WriteDomainError(w, r, "get codeowners failed", err)
+		return
+	}
+
+	httpserver.WriteJSON(w, http.StatusOK, toResponse(team.Name, *rs, doc))
+}
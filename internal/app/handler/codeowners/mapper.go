@@ -0,0 +1,28 @@
+package codeowners
+
+import (
+	domaincodeowners "github.com/user/reviewer-svc/internal/domain/codeowners"
+)
+
+func toResponse(teamName string, rs domaincodeowners.Ruleset, doc domaincodeowners.Document) CodeownersResponse {
+	rules := make([]Rule, 0, len(doc.Rules))
+	for _, r := range doc.Rules {
+		owners := r.Owners
+		if owners == nil {
+			owners = []string{}
+		}
+		rules = append(rules, Rule{
+			Line:     r.Line,
+			Pattern:  r.Pattern,
+			Owners:   owners,
+			Section:  r.Section,
+			Optional: r.Optional,
+		})
+	}
+	return CodeownersResponse{
+		TeamName:   teamName,
+		Codeowners: rs.Source,
+		Rules:      rules,
+		UpdatedAt:  rs.UpdatedAt,
+	}
+}
@@ -0,0 +1,17 @@
+package codeowners
+
+import (
+	"context"
+
+	domaincodeowners "github.com/user/reviewer-svc/internal/domain/codeowners"
+	"github.com/user/reviewer-svc/internal/domain/team"
+)
+
+type Service interface {
+	SetRules(ctx context.Context, teamID, src string) (*domaincodeowners.Ruleset, domaincodeowners.Document, error)
+	GetRules(ctx context.Context, teamID string) (*domaincodeowners.Ruleset, domaincodeowners.Document, error)
+}
+
+type TeamService interface {
+	GetTeamByName(ctx context.Context, name string) (*team.Team, error)
+}
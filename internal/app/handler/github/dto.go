@@ -0,0 +1,43 @@
+package github
+
+// PullRequestEvent is the subset of GitHub's pull_request webhook payload
+// the service relies on.
+type PullRequestEvent struct {
+	Action      string      `json:"action"`
+	Number      int64       `json:"number"`
+	PullRequest PullRequest `json:"pull_request"`
+	Repository  Repository  `json:"repository"`
+	Sender      Account     `json:"sender"`
+}
+
+type PullRequest struct {
+	Number int64   `json:"number"`
+	Title  string  `json:"title"`
+	Merged bool    `json:"merged"`
+	User   Account `json:"user"`
+}
+
+type Repository struct {
+	FullName string `json:"full_name"`
+}
+
+type Account struct {
+	Login string `json:"login"`
+}
+
+type WebhookResponse struct {
+	Event         string `json:"event"`
+	Action        string `json:"action,omitempty"`
+	Result        string `json:"result"`
+	PullRequestID string `json:"pull_request_id,omitempty"`
+	Status        string `json:"status,omitempty"`
+}
+
+type UserMapping struct {
+	GitHubLogin string `json:"github_login"`
+	UserID      string `json:"user_id"`
+}
+
+type ListUserMappingsResponse struct {
+	Items []UserMapping `json:"items"`
+}
@@ -0,0 +1,149 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/user/reviewer-svc/internal/app/httpserver"
+	"github.com/user/reviewer-svc/internal/domain"
+	domainintegration "github.com/user/reviewer-svc/internal/domain/integration"
+	domainpr "github.com/user/reviewer-svc/internal/domain/pr"
+)
+
+const maxPayloadSize = 5 << 20
+
+type Handler struct {
+	service Service
+	secret  string
+}
+
+func NewHandler(service Service, secret string) *Handler {
+	return &Handler{service: service, secret: secret}
+}
+
+// Authenticate rejects deliveries without a valid signature before any
+// other middleware, such as the idempotency one, sees them, and puts the
+// body back for Webhook, which must only be routed behind it.
+func (h *Handler) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxPayloadSize))
+		if err != nil {
+			httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "cannot read body", nil)
+			return
+		}
+		if !ValidSignature(h.secret, body, r.Header.Get(SignatureHeader)) {
+			httpserver.WriteDomainError(w, r, "github webhook: invalid signature", domain.ErrInvalidSignature)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// @Summary     GitHub webhook receiver
+// @Tags        integrations
+// @Accept      json
+// @Produce     json
+// @Param       X-GitHub-Event       header    string  true  "Event name"
+// @Param       X-Hub-Signature-256  header    string  true  "HMAC signature"
+// @Success     200                  {object}  WebhookResponse
+// @Failure     400                  {object}  httpserver.ErrorResponse
+// @Failure     401                  {object}  httpserver.ErrorResponse
+// @Failure     422                  {object}  httpserver.ErrorResponse
+// @Router      /integrations/github/webhook [post]
+func (h *Handler) Webhook(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxPayloadSize))
+	if err != nil {
+		httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "cannot read body", nil)
+		return
+	}
+
+	event := r.Header.Get("X-GitHub-Event")
+	if event != "pull_request" {
+		httpserver.WriteJSON(w, http.StatusOK, WebhookResponse{Event: event, Result: string(domainintegration.ResultIgnored)})
+		return
+	}
+
+	var ev PullRequestEvent
+	if err := json.Unmarshal(body, &ev); err != nil {
+		httpserver.Logger(r).Error("github webhook: invalid JSON", "err", err)
+		httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid JSON", nil)
+		return
+	}
+
+	number := ev.PullRequest.Number
+	if number == 0 {
+		number = ev.Number
+	}
+	prID := domainintegration.ExternalPRID(domainintegration.ProviderGitHub, ev.Repository.FullName, number)
+	actor := domainintegration.ProviderGitHub + ":" + ev.Sender.Login
+	httpserver.SetCaller(r, actor)
+	ctx := domain.WithActor(r.Context(), actor)
+
+	var pr *domainpr.PullRequest
+	result := domainintegration.ResultIgnored
+	switch {
+	case ev.Action == "opened" || ev.Action == "reopened":
+		pr, result, err = h.service.OpenPR(ctx, domainintegration.ProviderGitHub, prID, ev.PullRequest.Title, ev.PullRequest.User.Login)
+	case ev.Action == "closed" && ev.PullRequest.Merged:
+		pr, result, err = h.service.MergePR(ctx, prID)
+	case ev.Action == "closed":
+		pr, result, err = h.service.ClosePR(ctx, prID)
+	}
+	if err != nil {
+		httpserver.WriteDomainError(w, r, "github webhook failed", err, "action", ev.Action, "pull_request_id", prID)
+		return
+	}
+
+	resp := WebhookResponse{Event: event, Action: ev.Action, Result: string(result), PullRequestID: prID}
+	if pr != nil {
+		resp.Status = string(pr.Status)
+	}
+	httpserver.WriteJSON(w, http.StatusOK, resp)
+}
+
+// @Summary     Map GitHub login to user
+// @Tags        integrations
+// @Accept      json
+// @Produce     json
+// @Param       body  body      UserMapping  true  "Mapping"
+// @Success     200   {object}  UserMapping
+// @Failure     400   {object}  httpserver.ErrorResponse
+// @Router      /integrations/github/users [post]
+func (h *Handler) SetUserMapping(w http.ResponseWriter, r *http.Request) {
+	var req UserMapping
+	if err := httpserver.DecodeJSON(r, &req); err != nil {
+		httpserver.Logger(r).Error("github user mapping: invalid JSON", "err", err)
+		httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid JSON", nil)
+		return
+	}
+
+	m, err := h.service.SetMapping(r.Context(), domainintegration.ProviderGitHub, req.GitHubLogin, req.UserID)
+	if err != nil {
+		httpserver.WriteDomainError(w, r, "github user mapping failed", err)
+		return
+	}
+
+	httpserver.WriteJSON(w, http.StatusOK, UserMapping{GitHubLogin: m.ExternalLogin, UserID: m.UserID})
+}
+
+// @Summary     List GitHub login mappings
+// @Tags        integrations
+// @Produce     json
+// @Success     200  {object}  ListUserMappingsResponse
+// @Router      /integrations/github/users [get]
+func (h *Handler) ListUserMappings(w http.ResponseWriter, r *http.Request) {
+	list, err := h.service.ListMappings(r.Context(), domainintegration.ProviderGitHub)
+	if err != nil {
+		httpserver.WriteDomainError(w, r, "list github user mappings failed", err)
+		return
+	}
+
+	res := ListUserMappingsResponse{Items: make([]UserMapping, 0, len(list))}
+	for _, m := range list {
+		res.Items = append(res.Items, UserMapping{GitHubLogin: m.ExternalLogin, UserID: m.UserID})
+	}
+	httpserver.WriteJSON(w, http.StatusOK, res)
+}
@@ -0,0 +1,24 @@
+package github
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+const SignatureHeader = "X-Hub-Signature-256"
+
+// ValidSignature checks the X-Hub-Signature-256 header against body.
+func ValidSignature(secret string, body []byte, header string) bool {
+	if secret == "" || !strings.HasPrefix(header, "sha256=") {
+		return false
+	}
+	got, err := hex.DecodeString(strings.TrimPrefix(header, "sha256="))
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
@@ -0,0 +1,58 @@
+package gitlab
+
+// MergeRequestEvent is the subset of GitLab's merge request hook payload the
+// service relies on.
+type MergeRequestEvent struct {
+	ObjectKind       string           `json:"object_kind"`
+	User             Account          `json:"user"`
+	Project          Project          `json:"project"`
+	ObjectAttributes ObjectAttributes `json:"object_attributes"`
+	Reviewers        []Account        `json:"reviewers"`
+	Changes          Changes          `json:"changes"`
+}
+
+type Account struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	Username string `json:"username"`
+}
+
+type Project struct {
+	ID                int64  `json:"id"`
+	PathWithNamespace string `json:"path_with_namespace"`
+}
+
+type ObjectAttributes struct {
+	IID    int64  `json:"iid"`
+	Title  string `json:"title"`
+	State  string `json:"state"`
+	Action string `json:"action"`
+}
+
+type Changes struct {
+	Reviewers *ReviewersChange `json:"reviewers"`
+}
+
+type ReviewersChange struct {
+	Previous []Account `json:"previous"`
+	Current  []Account `json:"current"`
+}
+
+type WebhookResponse struct {
+	Event             string   `json:"event"`
+	Action            string   `json:"action,omitempty"`
+	Result            string   `json:"result"`
+	PullRequestID     string   `json:"pull_request_id,omitempty"`
+	Status            string   `json:"status,omitempty"`
+	AssignedReviewers []string `json:"assigned_reviewers,omitempty"`
+	UnmappedReviewers []string `json:"unmapped_reviewers,omitempty"`
+}
+
+type UserMapping struct {
+	GitLabUsername string `json:"gitlab_username"`
+	UserID         string `json:"user_id"`
+}
+
+type ListUserMappingsResponse struct {
+	Items []UserMapping `json:"items"`
+}
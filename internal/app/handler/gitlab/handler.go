@@ -0,0 +1,161 @@
+package gitlab
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/user/reviewer-svc/internal/app/httpserver"
+	"github.com/user/reviewer-svc/internal/domain"
+	domainintegration "github.com/user/reviewer-svc/internal/domain/integration"
+	domainpr "github.com/user/reviewer-svc/internal/domain/pr"
+)
+
+const (
+	TokenHeader    = "X-Gitlab-Token"
+	maxPayloadSize = 5 << 20
+)
+
+type Handler struct {
+	service Service
+	token   string
+}
+
+func NewHandler(service Service, token string) *Handler {
+	return &Handler{service: service, token: token}
+}
+
+// Authenticate rejects deliveries without the secret token before any other
+// middleware, such as the idempotency one, sees them. Webhook must only be
+// routed behind it.
+func (h *Handler) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got := r.Header.Get(TokenHeader)
+		if h.token == "" || subtle.ConstantTimeCompare([]byte(got), []byte(h.token)) != 1 {
+			httpserver.WriteDomainError(w, r, "gitlab webhook: invalid token", domain.ErrInvalidSignature)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// @Summary     GitLab merge request webhook receiver
+// @Tags        integrations
+// @Accept      json
+// @Produce     json
+// @Param       X-Gitlab-Token  header    string  true  "Secret token"
+// @Success     200             {object}  WebhookResponse
+// @Failure     400             {object}  httpserver.ErrorResponse
+// @Failure     401             {object}  httpserver.ErrorResponse
+// @Failure     422             {object}  httpserver.ErrorResponse
+// @Router      /integrations/gitlab/webhook [post]
+func (h *Handler) Webhook(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxPayloadSize))
+	if err != nil {
+		httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "cannot read body", nil)
+		return
+	}
+
+	var ev MergeRequestEvent
+	if err := json.Unmarshal(body, &ev); err != nil {
+		httpserver.Logger(r).Error("gitlab webhook: invalid JSON", "err", err)
+		httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid JSON", nil)
+		return
+	}
+	if ev.ObjectKind != "merge_request" {
+		httpserver.WriteJSON(w, http.StatusOK, WebhookResponse{Event: ev.ObjectKind, Result: string(domainintegration.ResultIgnored)})
+		return
+	}
+
+	attrs := ev.ObjectAttributes
+	prID := domainintegration.ExternalPRID(domainintegration.ProviderGitLab, ev.Project.PathWithNamespace, attrs.IID)
+	actor := domainintegration.ProviderGitLab + ":" + ev.User.Username
+	httpserver.SetCaller(r, actor)
+	ctx := domain.WithActor(r.Context(), actor)
+	resp := WebhookResponse{Event: ev.ObjectKind, Action: attrs.Action, PullRequestID: prID}
+
+	var pr *domainpr.PullRequest
+	result := domainintegration.ResultIgnored
+	switch attrs.Action {
+	case "open", "reopen":
+		pr, result, err = h.service.OpenPR(ctx, domainintegration.ProviderGitLab, prID, attrs.Title, ev.User.Username)
+		if err == nil && len(ev.Reviewers) > 0 {
+			pr, resp.UnmappedReviewers, err = h.service.SyncReviewers(ctx, domainintegration.ProviderGitLab, prID, usernames(ev.Reviewers))
+		}
+	case "update":
+		if ev.Changes.Reviewers != nil {
+			pr, resp.UnmappedReviewers, err = h.service.SyncReviewers(ctx, domainintegration.ProviderGitLab, prID, usernames(ev.Changes.Reviewers.Current))
+			result = domainintegration.ResultSynced
+		}
+	case "merge":
+		pr, result, err = h.service.MergePR(ctx, prID)
+	case "close":
+		pr, result, err = h.service.ClosePR(ctx, prID)
+	}
+	if err != nil {
+		httpserver.WriteDomainError(w, r, "gitlab webhook failed", err, "action", attrs.Action, "pull_request_id", prID)
+		return
+	}
+
+	resp.Result = string(result)
+	if pr != nil {
+		resp.Status = string(pr.Status)
+		for _, rv := range pr.Reviewers {
+			resp.AssignedReviewers = append(resp.AssignedReviewers, rv.UserID)
+		}
+	}
+	httpserver.WriteJSON(w, http.StatusOK, resp)
+}
+
+// @Summary     Map GitLab username to user
+// @Tags        integrations
+// @Accept      json
+// @Produce     json
+// @Param       body  body      UserMapping  true  "Mapping"
+// @Success     200   {object}  UserMapping
+// @Failure     400   {object}  httpserver.ErrorResponse
+// @Router      /integrations/gitlab/users [post]
+func (h *Handler) SetUserMapping(w http.ResponseWriter, r *http.Request) {
+	var req UserMapping
+	if err := httpserver.DecodeJSON(r, &req); err != nil {
+		httpserver.Logger(r).Error("gitlab user mapping: invalid JSON", "err", err)
+		httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid JSON", nil)
+		return
+	}
+
+	m, err := h.service.SetMapping(r.Context(), domainintegration.ProviderGitLab, req.GitLabUsername, req.UserID)
+	if err != nil {
+		httpserver.WriteDomainError(w, r, "gitlab user mapping failed", err)
+		return
+	}
+
+	httpserver.WriteJSON(w, http.StatusOK, UserMapping{GitLabUsername: m.ExternalLogin, UserID: m.UserID})
+}
+
+// @Summary     List GitLab username mappings
+// @Tags        integrations
+// @Produce     json
+// @Success     200  {object}  ListUserMappingsResponse
+// @Router      /integrations/gitlab/users [get]
+func (h *Handler) ListUserMappings(w http.ResponseWriter, r *http.Request) {
+	list, err := h.service.ListMappings(r.Context(), domainintegration.ProviderGitLab)
+	if err != nil {
+		httpserver.WriteDomainError(w, r, "list gitlab user mappings failed", err)
+		return
+	}
+
+	res := ListUserMappingsResponse{Items: make([]UserMapping, 0, len(list))}
+	for _, m := range list {
+		res.Items = append(res.Items, UserMapping{GitLabUsername: m.ExternalLogin, UserID: m.UserID})
+	}
+	httpserver.WriteJSON(w, http.StatusOK, res)
+}
+
+func usernames(accounts []Account) []string {
+	res := make([]string, 0, len(accounts))
+	for _, a := range accounts {
+		res = append(res, a.Username)
+	}
+	return res
+}
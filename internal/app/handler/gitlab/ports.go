@@ -0,0 +1,17 @@
+package gitlab
+
+import (
+	"context"
+
+	domainintegration "github.com/user/reviewer-svc/internal/domain/integration"
+	domainpr "github.com/user/reviewer-svc/internal/domain/pr"
+)
+
+type Service interface {
+	SetMapping(ctx context.Context, provider, login, userID string) (*domainintegration.UserMapping, error)
+	ListMappings(ctx context.Context, provider string) ([]domainintegration.UserMapping, error)
+	OpenPR(ctx context.Context, provider, prID, title, authorLogin string) (*domainpr.PullRequest, domainintegration.Result, error)
+	MergePR(ctx context.Context, prID string) (*domainpr.PullRequest, domainintegration.Result, error)
+	ClosePR(ctx context.Context, prID string) (*domainpr.PullRequest, domainintegration.Result, error)
+	SyncReviewers(ctx context.Context, provider, prID string, logins []string) (*domainpr.PullRequest, []string, error)
+}
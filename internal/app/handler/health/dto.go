@@ -0,0 +1,13 @@
+package health
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// ReadinessResponse reports each database pool; Replica is only present
+// when a read replica is configured.
+type ReadinessResponse struct {
+	Primary string `json:"primary"`
+	Replica string `json:"replica,omitempty"`
+}
@@ -5,22 +5,22 @@ import (
 	"net/http"
 	"time"
 
-	"log/slog"
-
 	"github.com/user/reviewer-svc/internal/app/httpserver"
 )
 
 type Handler struct {
-	log *slog.Logger
-	db  DBPinger
+	db      DBPinger
+	replica DBPinger
 }
 
 type DBPinger interface {
 	Ping(ctx context.Context) error
 }
 
-func NewHandler(log *slog.Logger, db DBPinger) *Handler {
-	return &Handler{log: log, db: db}
+// NewHandler builds the probes; replica may be nil when reads are not
+// routed to a replica.
+func NewHandler(db DBPinger, replica DBPinger) *Handler {
+	return &Handler{db: db, replica: replica}
 }
 
 
@@ -34,8 +34,10 @@ func (h *Handler) Healthz(w http.ResponseWriter, _ *http.Request) {
 
 
 // @Summary     Readiness probe
+// @Description A replica that is down does not fail the probe: reads fall back to the primary.
 // @Tags        health
-// @Success     200
+// @Produce     json
+// @Success     200 {object} ReadinessResponse
 // @Failure     503 {object} httpserver.ErrorResponse
 // @Router      /readyz [get]
 func (h *Handler) Readyz(w http.ResponseWriter, r *http.Request) {
@@ -43,10 +45,19 @@ func (h *Handler) Readyz(w http.ResponseWriter, r *http.Request) {
 	defer cancel()
 
 	if err := h.db.Ping(ctx); err != nil {
-		h.log.Error("readyz: database not ready", "err", err)
+		httpserver.Logger(r).Error("readyz: database not ready", "err", err)
 		httpserver.WriteError(w, http.StatusServiceUnavailable, "not_ready", "database not ready", nil)
 		return
 	}
 
-	httpserver.WriteNoContent(w)
+	resp := ReadinessResponse{Primary: StatusUp}
+	if h.replica != nil {
+		resp.Replica = StatusUp
+		if err := h.replica.Ping(ctx); err != nil {
+			httpserver.Logger(r).Warn("readyz: replica not ready, reads use the primary", "err", err)
+			resp.Replica = StatusDown
+		}
+	}
+
+	httpserver.WriteJSON(w, http.StatusOK, resp)
 }
@@ -0,0 +1,124 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/user/reviewer-svc/internal/app/httpserver"
+	"github.com/user/reviewer-svc/internal/domain"
+	domainidempotency "github.com/user/reviewer-svc/internal/domain/idempotency"
+)
+
+// HeaderKey names the key a client picks for a request and sends again
+// with every retry of it.
+const HeaderKey = "Idempotency-Key"
+
+// HeaderReplayed marks responses that were stored and replayed rather than
+// produced by handling the request again.
+const HeaderReplayed = "Idempotent-Replayed"
+
+// maxBodySize bounds the body read into memory to hash and replay a
+// request; it matches the largest payload the code host webhooks accept.
+const maxBodySize = 5 << 20
+
+type Service interface {
+	Begin(ctx context.Context, scope, key, requestHash string) (*domainidempotency.Record, error)
+	Finish(ctx context.Context, scope, key string, status int, contentType string, body []byte) error
+	Abandon(ctx context.Context, scope, key string) error
+}
+
+// Middleware makes POST requests sent with an Idempotency-Key header safe
+// to retry: the first response is stored and replayed for retries, while a
+// key sent again with a different method, path or body is rejected. It has
+// to run after authentication, because keys are scoped to the actor;
+// requests without credentials are scoped to the client address instead.
+// Server errors are not stored, so that the retry gets another chance.
+func Middleware(svc Service) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(HeaderKey)
+			if r.Method != http.MethodPost || key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				httpserver.WriteError(w, http.StatusRequestEntityTooLarge, "REQUEST_TOO_LARGE", "request body too large", nil)
+				return
+			}
+			if err != nil {
+				httpserver.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "cannot read request body", nil)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			scope := requestScope(r)
+			rec, err := svc.Begin(r.Context(), scope, key, requestHash(r, body))
+			if err != nil {
+				httpserver.WriteDomainError(w, r, "idempotency check failed", err)
+				return
+			}
+			if rec != nil {
+				if rec.ContentType != "" {
+					w.Header().Set("Content-Type", rec.ContentType)
+				}
+				w.Header().Set(HeaderReplayed, "true")
+				w.WriteHeader(rec.Status)
+				_, _ = w.Write(rec.Body)
+				return
+			}
+
+			var buf bytes.Buffer
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			ww.Tee(&buf)
+			next.ServeHTTP(ww, r)
+
+			// The request may have timed out; the outcome is recorded anyway.
+			ctx := context.WithoutCancel(r.Context())
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			if status >= http.StatusInternalServerError {
+				err = svc.Abandon(ctx, scope, key)
+			} else {
+				err = svc.Finish(ctx, scope, key, status, ww.Header().Get("Content-Type"), buf.Bytes())
+			}
+			if err != nil {
+				httpserver.Logger(r).Error("store idempotent response failed", "err", err, "status", status)
+			}
+		})
+	}
+}
+
+// requestScope separates the keys of different callers. Anonymous callers,
+// let through when authentication is not required, would otherwise share
+// one scope and could replay each other's responses.
+func requestScope(r *http.Request) string {
+	if actor, ok := domain.LookupActor(r.Context()); ok {
+		return actor
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "anonymous:" + host
+}
+
+// requestHash identifies a request by everything a retry has to repeat.
+func requestHash(r *http.Request, body []byte) string {
+	h := sha256.New()
+	io.WriteString(h, r.Method+" "+r.URL.RequestURI()+"\n")
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -3,8 +3,10 @@ package prs
 type PRStatus string
 
 const (
+	StatusDraft  PRStatus = "DRAFT"
 	StatusOpen   PRStatus = "OPEN"
 	StatusMerged PRStatus = "MERGED"
+	StatusClosed PRStatus = "CLOSED"
 )
 
 type PullRequest struct {
@@ -13,10 +15,23 @@ type PullRequest struct {
 	AuthorID         string    `json:"author_id"`
 	Status           PRStatus  `json:"status"`
 	AssignedReviewers []string `json:"assigned_reviewers"`
+	Reviews          []Review  `json:"reviews"`
 	CreatedAt        *string   `json:"createdAt,omitempty"`
 	MergedAt         *string   `json:"mergedAt,omitempty"`
 }
 
+type Review struct {
+	UserID     string  `json:"user_id"`
+	Verdict    string  `json:"verdict,omitempty"`
+	ReviewedAt *string `json:"reviewed_at,omitempty"`
+	// OverCapacity is set when the reviewer was assigned beyond their
+	// max_open_reviews because the whole team was saturated.
+	OverCapacity bool `json:"over_capacity,omitempty"`
+	// FallbackTeam names the team the reviewer was borrowed from because
+	// the author's team could not fill the slot.
+	FallbackTeam string `json:"fallback_team,omitempty"`
+}
+
 type PullRequestShort struct {
 	PullRequestID   string   `json:"pull_request_id"`
 	PullRequestName string   `json:"pull_request_name"`
@@ -28,10 +43,18 @@ type CreatePRRequest struct {
 	PullRequestID   string `json:"pull_request_id"`
 	PullRequestName string `json:"pull_request_name"`
 	AuthorID        string `json:"author_id"`
+	// Draft opens the PR without reviewers until it is marked ready.
+	Draft bool `json:"draft,omitempty"`
+	// ChangedFiles are the paths the PR touches, matched against the
+	// CODEOWNERS rules of the author's team.
+	ChangedFiles []string `json:"changed_files,omitempty"`
+	// RequiredTags are the skills the reviewers should cover between them.
+	RequiredTags []string `json:"required_tags,omitempty"`
 }
 
 type CreatePRResponse struct {
-	PR PullRequest `json:"pr"`
+	PR           PullRequest `json:"pr"`
+	OverCapacity bool        `json:"over_capacity,omitempty"`
 }
 
 type MergePRRequest struct {
@@ -42,17 +65,59 @@ type MergePRResponse struct {
 	PR PullRequest `json:"pr"`
 }
 
+// TransitionPRRequest marks a PR ready, closes or reopens it.
+type TransitionPRRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+}
+
+type TransitionPRResponse struct {
+	PR PullRequest `json:"pr"`
+}
+
+type SubmitReviewRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+	ReviewerID    string `json:"reviewer_id"`
+	Verdict       string `json:"verdict"`
+}
+
+type SubmitReviewResponse struct {
+	PR PullRequest `json:"pr"`
+}
+
 type ReassignReviewerRequest struct {
 	PullRequestID string `json:"pull_request_id"`
 	OldUserID     string `json:"old_user_id"`
 }
 
 type ReassignReviewerResponse struct {
-	PR         PullRequest `json:"pr"`
-	ReplacedBy string      `json:"replaced_by"`
+	PR           PullRequest `json:"pr"`
+	ReplacedBy   string      `json:"replaced_by"`
+	OverCapacity bool        `json:"over_capacity,omitempty"`
+	FallbackTeam string      `json:"fallback_team,omitempty"`
 }
 
 type GetReviewResponse struct {
 	UserID       string             `json:"user_id"`
 	PullRequests []PullRequestShort `json:"pull_requests"`
+	NextCursor   string             `json:"next_cursor,omitempty"`
+}
+
+type ListPRsResponse struct {
+	PullRequests []PullRequest `json:"pull_requests"`
+	NextCursor   string        `json:"next_cursor,omitempty"`
+}
+
+type AssignmentEvent struct {
+	Type      string  `json:"type"`
+	Slot      *int    `json:"slot,omitempty"`
+	OldUserID *string `json:"old_user_id,omitempty"`
+	NewUserID *string `json:"new_user_id,omitempty"`
+	Actor     string  `json:"actor"`
+	Reason    string  `json:"reason"`
+	CreatedAt string  `json:"createdAt"`
+}
+
+type HistoryResponse struct {
+	PullRequestID string            `json:"pull_request_id"`
+	Events        []AssignmentEvent `json:"events"`
 }
@@ -1,10 +1,9 @@
 package prs
 
 import (
+	"context"
 	"net/http"
 
-	"log/slog"
-
 	chi "github.com/go-chi/chi/v5"
 
 	"github.com/user/reviewer-svc/internal/app/httpserver"
@@ -14,11 +13,10 @@ import (
 
 type Handler struct {
 	service Service
-	log     *slog.Logger
 }
 
-func NewHandler(service Service, log *slog.Logger) *Handler {
-	return &Handler{service: service, log: log}
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
 }
 
 
@@ -34,28 +32,33 @@ func NewHandler(service Service, log *slog.Logger) *Handler {
 func (h *Handler) CreatePR(w http.ResponseWriter, r *http.Request) {
 	var req CreatePRRequest
 	if err := httpserver.DecodeJSON(r, &req); err != nil {
-		h.log.Error("create pr: invalid JSON", "err", err)
+		httpserver.Logger(r).Error("create pr: invalid JSON", "err", err)
 		httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid JSON", nil)
 		return
 	}
 
-	pr, err := h.service.CreatePRByID(r.Context(), req.PullRequestID, req.PullRequestName, req.AuthorID)
+	create := h.service.CreatePRByID
+	if req.Draft {
+		create = h.service.CreateDraftPRByID
+	}
+	pr, err := create(r.Context(), req.PullRequestID, req.PullRequestName, req.AuthorID, req.ChangedFiles, req.RequiredTags)
 	if err != nil {
-		status, code := httpserver.MapError(err)
-		h.log.Error("create pr failed", "err", err, "code", code)
-		httpserver.WriteError(w, status, code, err.Error(), nil)
+		httpserver.WriteDomainError(w, r, "create pr failed", err)
 		return
 	}
 
-	httpserver.WriteJSON(w, http.StatusCreated, CreatePRResponse{PR: toResponse(*pr)})
+	httpserver.WriteJSON(w, http.StatusCreated, CreatePRResponse{PR: toResponse(*pr), OverCapacity: pr.OverCapacity()})
 }
 
 
 // @Summary     List pull requests
 // @Tags        prs
 // @Produce     json
-// @Param       status  query     string  false  "PR status (OPEN|MERGED)"
-// @Success     200     {array}   PullRequest
+// @Param       status  query     string  false  "PR status (DRAFT|OPEN|MERGED|CLOSED)"
+// @Param       limit   query     int     false  "Page size"
+// @Param       cursor  query     string  false  "next_cursor of the previous page"
+// @Success     200     {object}  ListPRsResponse
+// @Failure     400     {object}  httpserver.ErrorResponse
 // @Router      /prs [get]
 func (h *Handler) ListPRs(w http.ResponseWriter, r *http.Request) {
 	q := r.URL.Query()
@@ -63,22 +66,33 @@ func (h *Handler) ListPRs(w http.ResponseWriter, r *http.Request) {
 	var status *domainpr.PRStatus
 	if v := q.Get("status"); v != "" {
 		st := domainpr.PRStatus(v)
+		if !domainpr.ValidStatus(st) {
+			httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "unknown status", nil)
+			return
+		}
 		status = &st
 	}
 
-	prs, err := h.service.ListPRs(r.Context(), status)
+	page, err := httpserver.ParsePageRequest(r)
 	if err != nil {
-		statusCode, code := httpserver.MapError(err)
-		h.log.Error("list prs failed", "err", err, "code", code)
-		httpserver.WriteError(w, statusCode, code, err.Error(), nil)
+		httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
 		return
 	}
 
-	res := make([]PullRequest, 0, len(prs))
-	for _, p := range prs {
+	prs, err := h.service.ListPRs(r.Context(), status, page)
+	if err != nil {
+		httpserver.WriteDomainError(w, r, "list prs failed", err)
+		return
+	}
+
+	res := make([]PullRequest, 0, len(prs.Items))
+	for _, p := range prs.Items {
 		res = append(res, toResponse(p))
 	}
-	httpserver.WriteJSON(w, http.StatusOK, res)
+	httpserver.WriteJSON(w, http.StatusOK, ListPRsResponse{
+		PullRequests: res,
+		NextCursor:   httpserver.EncodeCursor(prs.NextCursor),
+	})
 }
 
 
@@ -95,9 +109,7 @@ func (h *Handler) GetPR(w http.ResponseWriter, r *http.Request) {
 
 	prResult, err := h.service.GetPRByID(r.Context(), id)
 	if err != nil {
-		status, code := httpserver.MapError(err)
-		h.log.Error("get pr failed", "err", err, "code", code)
-		httpserver.WriteError(w, status, code, err.Error(), nil)
+		httpserver.WriteDomainError(w, r, "get pr failed", err)
 		return
 	}
 
@@ -119,22 +131,22 @@ func (h *Handler) GetPR(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) ReassignReviewer(w http.ResponseWriter, r *http.Request) {
 	var req ReassignReviewerRequest
 	if err := httpserver.DecodeJSON(r, &req); err != nil {
-		h.log.Error("reassign reviewer: invalid JSON", "err", err)
+		httpserver.Logger(r).Error("reassign reviewer: invalid JSON", "err", err)
 		httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid JSON", nil)
 		return
 	}
 
 	pr, newReviewerID, err := h.service.ReassignReviewerByID(r.Context(), req.PullRequestID, req.OldUserID)
 	if err != nil {
-		status, code := httpserver.MapError(err)
-		h.log.Error("reassign reviewer failed", "err", err, "code", code)
-		httpserver.WriteError(w, status, code, err.Error(), nil)
+		httpserver.WriteDomainError(w, r, "reassign reviewer failed", err)
 		return
 	}
 
 	httpserver.WriteJSON(w, http.StatusOK, ReassignReviewerResponse{
-		PR:         toResponse(*pr),
-		ReplacedBy: newReviewerID,
+		PR:           toResponse(*pr),
+		ReplacedBy:   newReviewerID,
+		OverCapacity: reviewerOverCapacity(*pr, newReviewerID),
+		FallbackTeam: reviewerFallbackTeam(*pr, newReviewerID),
 	})
 }
 
@@ -146,33 +158,125 @@ func (h *Handler) ReassignReviewer(w http.ResponseWriter, r *http.Request) {
 // @Success     200   {object}  PullRequest
 // @Failure     400   {object}  httpserver.ErrorResponse
 // @Failure     404   {object}  httpserver.ErrorResponse
+// @Failure     409   {object}  httpserver.ErrorResponse
 // @Router      /prs/{prId}/merge [post]
 func (h *Handler) MergePR(w http.ResponseWriter, r *http.Request) {
 	var req MergePRRequest
 	if err := httpserver.DecodeJSON(r, &req); err != nil {
-		h.log.Error("merge pr: invalid JSON", "err", err)
+		httpserver.Logger(r).Error("merge pr: invalid JSON", "err", err)
 		httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid JSON", nil)
 		return
 	}
 
 	pr, err := h.service.MergePRByID(r.Context(), req.PullRequestID)
 	if err != nil {
-		status, code := httpserver.MapError(err)
-		h.log.Error("merge pr failed", "err", err, "code", code)
-		httpserver.WriteError(w, status, code, err.Error(), nil)
+		httpserver.WriteDomainError(w, r, "merge pr failed", err)
 		return
 	}
 
 	httpserver.WriteJSON(w, http.StatusOK, MergePRResponse{PR: toResponse(*pr)})
 }
 
+// @Summary     Mark draft PR ready for review and assign reviewers
+// @Tags        prs
+// @Accept      json
+// @Produce     json
+// @Param       body  body      TransitionPRRequest  true  "PR payload"
+// @Success     200   {object}  TransitionPRResponse
+// @Failure     404   {object}  httpserver.ErrorResponse
+// @Failure     409   {object}  httpserver.ErrorResponse
+// @Router      /pullRequest/ready [post]
+func (h *Handler) MarkReady(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, "mark pr ready", h.service.MarkReadyByID)
+}
+
+// @Summary     Close PR without merging and release its reviewers
+// @Tags        prs
+// @Accept      json
+// @Produce     json
+// @Param       body  body      TransitionPRRequest  true  "PR payload"
+// @Success     200   {object}  TransitionPRResponse
+// @Failure     404   {object}  httpserver.ErrorResponse
+// @Failure     409   {object}  httpserver.ErrorResponse
+// @Router      /pullRequest/close [post]
+func (h *Handler) ClosePR(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, "close pr", h.service.ClosePRByID)
+}
+
+// @Summary     Reopen closed PR and assign new reviewers
+// @Tags        prs
+// @Accept      json
+// @Produce     json
+// @Param       body  body      TransitionPRRequest  true  "PR payload"
+// @Success     200   {object}  TransitionPRResponse
+// @Failure     404   {object}  httpserver.ErrorResponse
+// @Failure     409   {object}  httpserver.ErrorResponse
+// @Router      /pullRequest/reopen [post]
+func (h *Handler) ReopenPR(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, "reopen pr", h.service.ReopenPRByID)
+}
+
+func (h *Handler) transition(w http.ResponseWriter, r *http.Request, action string, apply func(ctx context.Context, prID string) (*domainpr.PullRequest, error)) {
+	var req TransitionPRRequest
+	if err := httpserver.DecodeJSON(r, &req); err != nil {
+		httpserver.Logger(r).Error(action+": invalid JSON", "err", err)
+		httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid JSON", nil)
+		return
+	}
+	if req.PullRequestID == "" {
+		httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "pull_request_id is required", nil)
+		return
+	}
+
+	pr, err := apply(r.Context(), req.PullRequestID)
+	if err != nil {
+		httpserver.WriteDomainError(w, r, action+" failed", err)
+		return
+	}
+
+	httpserver.WriteJSON(w, http.StatusOK, TransitionPRResponse{PR: toResponse(*pr)})
+}
+
+// @Summary     Submit reviewer verdict
+// @Tags        prs
+// @Accept      json
+// @Produce     json
+// @Param       body  body      SubmitReviewRequest  true  "Review payload"
+// @Success     200   {object}  SubmitReviewResponse
+// @Failure     400   {object}  httpserver.ErrorResponse
+// @Failure     404   {object}  httpserver.ErrorResponse
+// @Failure     409   {object}  httpserver.ErrorResponse
+// @Router      /pullRequest/review [post]
+func (h *Handler) SubmitReview(w http.ResponseWriter, r *http.Request) {
+	var req SubmitReviewRequest
+	if err := httpserver.DecodeJSON(r, &req); err != nil {
+		httpserver.Logger(r).Error("submit review: invalid JSON", "err", err)
+		httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid JSON", nil)
+		return
+	}
+	if req.PullRequestID == "" || req.ReviewerID == "" {
+		httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "pull_request_id and reviewer_id are required", nil)
+		return
+	}
+
+	pr, err := h.service.SubmitReviewByID(r.Context(), req.PullRequestID, req.ReviewerID, domainpr.ReviewVerdict(req.Verdict))
+	if err != nil {
+		httpserver.WriteDomainError(w, r, "submit review failed", err)
+		return
+	}
+
+	httpserver.WriteJSON(w, http.StatusOK, SubmitReviewResponse{PR: toResponse(*pr)})
+}
+
 
 // @Summary     List PRs assigned to user as reviewer
 // @Tags        prs
 // @Produce     json
 // @Param       userId  path      string  true  "User ID"
 // @Param       status  query     string  false "PR status (OPEN|MERGED)"
-// @Success     200     {array}   PullRequest
+// @Param       limit   query     int     false "Page size"
+// @Param       cursor  query     string  false "next_cursor of the previous page"
+// @Success     200     {object}  GetReviewResponse
 // @Failure     400     {object}  httpserver.ErrorResponse
 // @Failure     404     {object}  httpserver.ErrorResponse
 // @Router      /users/{userId}/assigned-prs [get]
@@ -183,21 +287,55 @@ func (h *Handler) ListAssignedPRs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	prs, err := h.service.ListAssignedPRsByID(r.Context(), userIDStr, nil)
+	page, err := httpserver.ParsePageRequest(r)
+	if err != nil {
+		httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+		return
+	}
+
+	prs, err := h.service.ListAssignedPRsByID(r.Context(), userIDStr, nil, page)
 	if err != nil {
-		statusCode, code := httpserver.MapError(err)
-		h.log.Error("list assigned prs failed", "err", err, "code", code)
-		httpserver.WriteError(w, statusCode, code, err.Error(), nil)
+		httpserver.WriteDomainError(w, r, "list assigned prs failed", err)
 		return
 	}
 
-	prList := make([]PullRequestShort, 0, len(prs))
-	for _, p := range prs {
+	prList := make([]PullRequestShort, 0, len(prs.Items))
+	for _, p := range prs.Items {
 		prList = append(prList, toShortResponse(p))
 	}
 	
 	httpserver.WriteJSON(w, http.StatusOK, GetReviewResponse{
 		UserID:       userIDStr,
 		PullRequests: prList,
+		NextCursor:   httpserver.EncodeCursor(prs.NextCursor),
 	})
 }
+
+
+// @Summary     Reviewer assignment history of PR
+// @Tags        prs
+// @Produce     json
+// @Param       pull_request_id  query     string  true  "PR ID"
+// @Success     200              {object}  HistoryResponse
+// @Failure     400              {object}  httpserver.ErrorResponse
+// @Failure     404              {object}  httpserver.ErrorResponse
+// @Router      /pullRequest/history [get]
+func (h *Handler) GetHistory(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "pull_request_id is required", nil)
+		return
+	}
+
+	events, err := h.service.HistoryByID(r.Context(), prID)
+	if err != nil {
+		httpserver.WriteDomainError(w, r, "pr history failed", err)
+		return
+	}
+
+	res := HistoryResponse{PullRequestID: prID, Events: make([]AssignmentEvent, 0, len(events))}
+	for _, e := range events {
+		res.Events = append(res.Events, toEventResponse(e))
+	}
+	httpserver.WriteJSON(w, http.StatusOK, res)
+}
@@ -4,8 +4,10 @@ import domainpr "github.com/user/reviewer-svc/internal/domain/pr"
 
 func toResponse(pr domainpr.PullRequest) PullRequest {
 	assignedReviewers := make([]string, 0, len(pr.Reviewers))
+	reviews := make([]Review, 0, len(pr.Reviewers))
 	for _, rv := range pr.Reviewers {
 		assignedReviewers = append(assignedReviewers, rv.UserID)
+		reviews = append(reviews, toReviewResponse(rv))
 	}
 
 	var createdAt *string
@@ -26,11 +28,45 @@ func toResponse(pr domainpr.PullRequest) PullRequest {
 		AuthorID:          pr.AuthorID,
 		Status:            PRStatus(pr.Status),
 		AssignedReviewers: assignedReviewers,
+		Reviews:           reviews,
 		CreatedAt:         createdAt,
 		MergedAt:          mergedAt,
 	}
 }
 
+func toReviewResponse(rv domainpr.PRReviewer) Review {
+	var reviewedAt *string
+	if rv.ReviewedAt != nil {
+		s := rv.ReviewedAt.Format("2006-01-02T15:04:05Z07:00")
+		reviewedAt = &s
+	}
+	return Review{
+		UserID:       rv.UserID,
+		Verdict:      string(rv.Verdict),
+		ReviewedAt:   reviewedAt,
+		OverCapacity: rv.OverCapacity,
+		FallbackTeam: rv.FallbackTeam,
+	}
+}
+
+func reviewerOverCapacity(pr domainpr.PullRequest, userID string) bool {
+	for _, rv := range pr.Reviewers {
+		if rv.UserID == userID {
+			return rv.OverCapacity
+		}
+	}
+	return false
+}
+
+func reviewerFallbackTeam(pr domainpr.PullRequest, userID string) string {
+	for _, rv := range pr.Reviewers {
+		if rv.UserID == userID {
+			return rv.FallbackTeam
+		}
+	}
+	return ""
+}
+
 func toShortResponse(pr domainpr.PullRequest) PullRequestShort {
 	return PullRequestShort{
 		PullRequestID:   pr.ID,
@@ -39,3 +75,15 @@ func toShortResponse(pr domainpr.PullRequest) PullRequestShort {
 		Status:          PRStatus(pr.Status),
 	}
 }
+
+func toEventResponse(e domainpr.AssignmentEvent) AssignmentEvent {
+	return AssignmentEvent{
+		Type:      string(e.Type),
+		Slot:      e.Slot,
+		OldUserID: e.OldUserID,
+		NewUserID: e.NewUserID,
+		Actor:     e.Actor,
+		Reason:    e.Reason,
+		CreatedAt: e.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
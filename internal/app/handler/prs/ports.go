@@ -3,14 +3,22 @@ package prs
 import (
 	"context"
 
+	"github.com/user/reviewer-svc/internal/domain"
 	domainpr "github.com/user/reviewer-svc/internal/domain/pr"
 )
 
 type Service interface {
-	CreatePRByID(ctx context.Context, prID, title, authorID string) (*domainpr.PullRequest, error)
+	CreatePRByID(ctx context.Context, prID, title, authorID string, changedFiles, requiredTags []string) (*domainpr.PullRequest, error)
+	CreateDraftPRByID(ctx context.Context, prID, title, authorID string, changedFiles, requiredTags []string) (*domainpr.PullRequest, error)
 	GetPRByID(ctx context.Context, id string) (*domainpr.PullRequest, error)
-	ListPRs(ctx context.Context, status *domainpr.PRStatus) ([]domainpr.PullRequest, error)
+	ListPRs(ctx context.Context, status *domainpr.PRStatus, page domain.PageRequest) (domain.Page[domainpr.PullRequest], error)
 	ReassignReviewerByID(ctx context.Context, prID, oldReviewerID string) (*domainpr.PullRequest, string, error)
 	MergePRByID(ctx context.Context, prID string) (*domainpr.PullRequest, error)
-	ListAssignedPRsByID(ctx context.Context, userID string, status *domainpr.PRStatus) ([]domainpr.PullRequest, error)
+	MarkReadyByID(ctx context.Context, prID string) (*domainpr.PullRequest, error)
+	ClosePRByID(ctx context.Context, prID string) (*domainpr.PullRequest, error)
+	ReopenPRByID(ctx context.Context, prID string) (*domainpr.PullRequest, error)
+	SubmitReviewByID(ctx context.Context, prID, reviewerID string, verdict domainpr.ReviewVerdict) (*domainpr.PullRequest, error)
+	SyncReviewersByID(ctx context.Context, prID string, userIDs []string) (*domainpr.PullRequest, error)
+	HistoryByID(ctx context.Context, prID string) ([]domainpr.AssignmentEvent, error)
+	ListAssignedPRsByID(ctx context.Context, userID string, status *domainpr.PRStatus, page domain.PageRequest) (domain.Page[domainpr.PullRequest], error)
 }
@@ -8,55 +8,173 @@ import (
 
 	chi "github.com/go-chi/chi/v5"
 
+	"github.com/user/reviewer-svc/internal/app/handler/absences"
+	"github.com/user/reviewer-svc/internal/app/handler/apikeys"
+	"github.com/user/reviewer-svc/internal/app/handler/auth"
+	"github.com/user/reviewer-svc/internal/app/handler/codeowners"
+	"github.com/user/reviewer-svc/internal/app/handler/github"
+	"github.com/user/reviewer-svc/internal/app/handler/gitlab"
 	"github.com/user/reviewer-svc/internal/app/handler/health"
+	"github.com/user/reviewer-svc/internal/app/handler/idempotency"
 	"github.com/user/reviewer-svc/internal/app/handler/prs"
 	"github.com/user/reviewer-svc/internal/app/handler/stats"
+	"github.com/user/reviewer-svc/internal/app/handler/tags"
 	"github.com/user/reviewer-svc/internal/app/handler/teams"
 	"github.com/user/reviewer-svc/internal/app/handler/users"
+	"github.com/user/reviewer-svc/internal/app/handler/webhooks"
+	"github.com/user/reviewer-svc/internal/app/httpserver"
+	domainapikey "github.com/user/reviewer-svc/internal/domain/apikey"
 )
 
 type DBPinger interface {
 	Ping(ctx context.Context) error
 }
 
+// Metrics instruments the router and serves the collected metrics.
+type Metrics interface {
+	Middleware(next http.Handler) http.Handler
+	Handler() http.Handler
+}
+
+// APIKeyService manages API keys and checks them on every guarded route.
+type APIKeyService interface {
+	apikeys.Service
+	auth.Authenticator
+}
+
+type Config struct {
+	GitHubWebhookSecret string
+	GitLabWebhookToken  string
+	// AccessLogSampleReads logs one in that many successful reads.
+	AccessLogSampleReads int
+	// AuthRequired rejects requests without an API key.
+	AuthRequired bool
+}
+
 type Deps struct {
 	Teams    teams.Service
 	Users    users.Service
 	UserBulk users.BulkService
+	Absences absences.Service
 	PRs      prs.Service
 	Stats    stats.Service
+	Webhooks webhooks.Service
+	GitHub   github.Service
+	GitLab   gitlab.Service
+	APIKeys  APIKeyService
+	Config   Config
 	Log      *slog.Logger
 	DB       DBPinger
+	// Replica is nil unless reads are routed to a read replica.
+	Replica DBPinger
+	Metrics Metrics
+	// Tokens verifies SSO bearer tokens; nil disables them.
+	Tokens      auth.TokenVerifier
+	Idempotency idempotency.Service
+	Codeowners  codeowners.Service
+	Tags        tags.Service
 }
 
 func NewRouter(r chi.Router, d Deps) http.Handler {
-	healthHandler := health.NewHandler(d.Log, d.DB)
-	teamHandler := teams.NewHandler(d.Teams, d.Users, d.Log)
-	userHandler := users.NewHandler(d.Users, d.UserBulk, d.Teams, d.Log)
-	prHandler := prs.NewHandler(d.PRs, d.Log)
-	statsHandler := stats.NewHandler(d.Stats, d.Log)
+	healthHandler := health.NewHandler(d.DB, d.Replica)
+	teamHandler := teams.NewHandler(d.Teams, d.Users)
+	codeownersHandler := codeowners.NewHandler(d.Codeowners, d.Teams)
+	userHandler := users.NewHandler(d.Users, d.UserBulk, d.Teams)
+	absenceHandler := absences.NewHandler(d.Absences)
+	tagHandler := tags.NewHandler(d.Tags)
+	prHandler := prs.NewHandler(d.PRs)
+	statsHandler := stats.NewHandler(d.Stats)
+	webhookHandler := webhooks.NewHandler(d.Webhooks)
+	githubHandler := github.NewHandler(d.GitHub, d.Config.GitHubWebhookSecret)
+	gitlabHandler := gitlab.NewHandler(d.GitLab, d.Config.GitLabWebhookToken)
+	apiKeyHandler := apikeys.NewHandler(d.APIKeys)
+
+	// Idempotency keys are scoped to the caller, so they are checked right
+	// after the guard, or the code host's signature check, of every route
+	// that takes POST requests.
+	idempotent := idempotency.Middleware(d.Idempotency)
+	guard := auth.NewGuard(d.APIKeys, d.Tokens, d.Users, d.Config.AuthRequired)
+	read := guard.Require(domainapikey.ScopeRead)
+	prWrite := chi.Chain(guard.Require(domainapikey.ScopePRWrite), idempotent).Handler
+	admin := chi.Chain(guard.Require(domainapikey.ScopeTeamAdmin), idempotent).Handler
 
+	r.Use(d.Metrics.Middleware)
+	r.Use(httpserver.RequestLogger(d.Log, d.Config.AccessLogSampleReads))
+
+	// Probes, metrics and code host webhooks, which carry their own
+	// signatures, are not guarded by API keys.
 	r.Get("/healthz", healthHandler.Healthz)
 	r.Get("/readyz", healthHandler.Readyz)
+	r.Method(http.MethodGet, "/metrics", d.Metrics.Handler())
 
 	r.Route("/team", func(r chi.Router) {
-		r.Post("/add", teamHandler.CreateTeam)
-		r.Get("/get", teamHandler.GetTeam)
+		r.With(admin).Post("/add", teamHandler.CreateTeam)
+		r.With(read).Get("/get", teamHandler.GetTeam)
+		r.With(admin).Post("/setRequiredReviewers", teamHandler.SetRequiredReviewers)
+		r.With(admin).Post("/setSaturationPolicy", teamHandler.SetSaturationPolicy)
+		r.With(admin).Post("/setFallbackTeams", teamHandler.SetFallbackTeams)
+		r.With(admin).Post("/setCodeowners", codeownersHandler.SetCodeowners)
+		r.With(read).Get("/codeowners", codeownersHandler.GetCodeowners)
 	})
 
+	r.With(read).Get("/teams", teamHandler.ListTeams)
+	r.With(admin).Post("/teams/{teamId}/deactivate-users", userHandler.BulkDeactivateUsers)
+
 	r.Route("/users", func(r chi.Router) {
-		r.Post("/setIsActive", userHandler.SetIsActive)
-		r.Get("/getReview", prHandler.ListAssignedPRs)
+		r.With(admin).Post("/setIsActive", userHandler.SetIsActive)
+		r.With(admin).Post("/setMaxOpenReviews", userHandler.SetMaxOpenReviews)
+		r.With(read).Get("/getReview", prHandler.ListAssignedPRs)
+		r.With(admin).Post("/absences", absenceHandler.CreateAbsence)
+		r.With(read).Get("/absences", absenceHandler.ListAbsences)
+		r.With(admin).Put("/absences/{absenceId}", absenceHandler.UpdateAbsence)
+		r.With(admin).Delete("/absences/{absenceId}", absenceHandler.DeleteAbsence)
+		r.With(read).Get("/tags", tagHandler.ListTags)
+		r.With(admin).Post("/tags", tagHandler.AddTags)
+		r.With(admin).Put("/tags", tagHandler.SetTags)
+		r.With(admin).Delete("/tags/{tag}", tagHandler.RemoveTag)
 	})
 
 	r.Route("/pullRequest", func(r chi.Router) {
-		r.Post("/create", prHandler.CreatePR)
-		r.Post("/merge", prHandler.MergePR)
-		r.Post("/reassign", prHandler.ReassignReviewer)
+		r.With(prWrite).Post("/create", prHandler.CreatePR)
+		r.With(prWrite).Post("/merge", prHandler.MergePR)
+		r.With(prWrite).Post("/ready", prHandler.MarkReady)
+		r.With(prWrite).Post("/close", prHandler.ClosePR)
+		r.With(prWrite).Post("/reopen", prHandler.ReopenPR)
+		r.With(prWrite).Post("/reassign", prHandler.ReassignReviewer)
+		r.With(prWrite).Post("/review", prHandler.SubmitReview)
+		r.With(read).Get("/history", prHandler.GetHistory)
 	})
 
 	r.Route("/stats", func(r chi.Router) {
-		r.Get("/assignments", statsHandler.GetAssignmentsStats)
+		r.With(read).Get("/assignments", statsHandler.GetAssignmentsStats)
+	})
+
+	r.Route("/webhooks", func(r chi.Router) {
+		r.Use(admin)
+		r.Post("/subscriptions", webhookHandler.CreateSubscription)
+		r.Get("/subscriptions", webhookHandler.ListSubscriptions)
+		r.Delete("/subscriptions/{subscriptionId}", webhookHandler.DeleteSubscription)
+		r.Get("/deadLetters", webhookHandler.ListDeadLetters)
+		r.Post("/deadLetters/{deliveryId}/retry", webhookHandler.RetryDeadLetter)
+	})
+
+	r.Route("/integrations/github", func(r chi.Router) {
+		r.With(githubHandler.Authenticate, idempotent).Post("/webhook", githubHandler.Webhook)
+		r.With(admin).Post("/users", githubHandler.SetUserMapping)
+		r.With(read).Get("/users", githubHandler.ListUserMappings)
+	})
+
+	r.Route("/integrations/gitlab", func(r chi.Router) {
+		r.With(gitlabHandler.Authenticate, idempotent).Post("/webhook", gitlabHandler.Webhook)
+		r.With(admin).Post("/users", gitlabHandler.SetUserMapping)
+		r.With(read).Get("/users", gitlabHandler.ListUserMappings)
+	})
+
+	r.Route("/apiKeys", func(r chi.Router) {
+		r.Use(admin)
+		r.Post("/", apiKeyHandler.CreateKey)
+		r.Get("/", apiKeyHandler.ListKeys)
+		r.Delete("/{keyId}", apiKeyHandler.RevokeKey)
 	})
 
 	return r
@@ -3,17 +3,14 @@ package stats
 import (
 	"net/http"
 
-	"log/slog"
-
 	"github.com/user/reviewer-svc/internal/app/httpserver"
 )
 type Handler struct {
 	service Service
-	log     *slog.Logger
 }
 
-func NewHandler(service Service, log *slog.Logger) *Handler {
-	return &Handler{service: service, log: log}
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
 }
 
 
@@ -45,9 +42,7 @@ func (h *Handler) GetAssignmentsStats(w http.ResponseWriter, r *http.Request) {
 	case "user":
 		stats, err := h.service.StatsByUser(ctx, teamID)
 		if err != nil {
-			status, code := httpserver.MapError(err)
-			h.log.Error("stats by user failed", "err", err, "code", code)
-			httpserver.WriteError(w, status, code, err.Error(), nil)
+			httpserver.WriteDomainError(w, r, "stats by user failed", err)
 			return
 		}
 		res := UserAssignmentsStatsResponse{Items: make([]UserAssignmentsStatsItem, 0, len(stats))}
@@ -62,9 +57,7 @@ func (h *Handler) GetAssignmentsStats(w http.ResponseWriter, r *http.Request) {
 	case "pr":
 		stats, err := h.service.StatsByPR(ctx, teamID)
 		if err != nil {
-			status, code := httpserver.MapError(err)
-			h.log.Error("stats by pr failed", "err", err, "code", code)
-			httpserver.WriteError(w, status, code, err.Error(), nil)
+			httpserver.WriteDomainError(w, r, "stats by pr failed", err)
 			return
 		}
 		res := PRAssignmentsStatsResponse{Items: make([]PRAssignmentsStatsItem, 0, len(stats))}
@@ -0,0 +1,13 @@
+package tags
+
+// UserTagsRequest names skill tags of user_id, such as "go" or "sql". Tags
+// are case-insensitive and stored lowercase.
+type UserTagsRequest struct {
+	UserID string   `json:"user_id"`
+	Tags   []string `json:"tags"`
+}
+
+type UserTagsResponse struct {
+	UserID string   `json:"user_id"`
+	Tags   []string `json:"tags"`
+}
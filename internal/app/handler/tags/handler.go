@@ -0,0 +1,136 @@
+package tags
+
+import (
+	"net/http"
+	"net/url"
+
+	chi "github.com/go-chi/chi/v5"
+
+	"github.com/user/reviewer-svc/internal/app/httpserver"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// @Summary     List user skill tags
+// @Tags        users
+// @Produce     json
+// @Param       user_id  query     string  true  "User ID"
+// @Success     200      {object}  UserTagsResponse
+// @Failure     400      {object}  httpserver.ErrorResponse
+// @Failure     404      {object}  httpserver.ErrorResponse
+// @Router      /users/tags [get]
+func (h *Handler) ListTags(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "user_id is required", nil)
+		return
+	}
+
+	tags, err := h.service.ListTags(r.Context(), userID)
+	if err != nil {
+		httpserver.WriteDomainError(w, r, "list tags failed", err)
+		return
+	}
+
+	httpserver.WriteJSON(w, http.StatusOK, UserTagsResponse{UserID: userID, Tags: tags})
+}
+
+// @Summary     Add user skill tags
+// @Tags        users
+// @Accept      json
+// @Produce     json
+// @Param       body  body      UserTagsRequest  true  "Tags to add"
+// @Success     200   {object}  UserTagsResponse
+// @Failure     400   {object}  httpserver.ErrorResponse
+// @Failure     404   {object}  httpserver.ErrorResponse
+// @Router      /users/tags [post]
+func (h *Handler) AddTags(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeRequest(w, r, "add tags")
+	if !ok {
+		return
+	}
+
+	tags, err := h.service.AddTags(r.Context(), req.UserID, req.Tags)
+	if err != nil {
+		httpserver.WriteDomainError(w, r, "add tags failed", err)
+		return
+	}
+
+	httpserver.WriteJSON(w, http.StatusOK, UserTagsResponse{UserID: req.UserID, Tags: tags})
+}
+
+// @Summary     Replace user skill tags
+// @Tags        users
+// @Accept      json
+// @Produce     json
+// @Param       body  body      UserTagsRequest  true  "New tags"
+// @Success     200   {object}  UserTagsResponse
+// @Failure     400   {object}  httpserver.ErrorResponse
+// @Failure     404   {object}  httpserver.ErrorResponse
+// @Router      /users/tags [put]
+func (h *Handler) SetTags(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeRequest(w, r, "set tags")
+	if !ok {
+		return
+	}
+
+	tags, err := h.service.SetTags(r.Context(), req.UserID, req.Tags)
+	if err != nil {
+		httpserver.WriteDomainError(w, r, "set tags failed", err)
+		return
+	}
+
+	httpserver.WriteJSON(w, http.StatusOK, UserTagsResponse{UserID: req.UserID, Tags: tags})
+}
+
+// @Summary     Remove a user skill tag
+// @Tags        users
+// @Produce     json
+// @Param       tag      path      string  true  "Tag"
+// @Param       user_id  query     string  true  "User ID"
+// @Success     200      {object}  UserTagsResponse
+// @Failure     400      {object}  httpserver.ErrorResponse
+// @Failure     404      {object}  httpserver.ErrorResponse
+// @Router      /users/tags/{tag} [delete]
+func (h *Handler) RemoveTag(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "user_id is required", nil)
+		return
+	}
+
+	// Tags such as "c#" arrive escaped, and chi matches the escaped path.
+	tag, err := url.PathUnescape(chi.URLParam(r, "tag"))
+	if err != nil {
+		httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid tag", nil)
+		return
+	}
+
+	tags, err := h.service.RemoveTag(r.Context(), userID, tag)
+	if err != nil {
+		httpserver.WriteDomainError(w, r, "remove tag failed", err)
+		return
+	}
+
+	httpserver.WriteJSON(w, http.StatusOK, UserTagsResponse{UserID: userID, Tags: tags})
+}
+
+func decodeRequest(w http.ResponseWriter, r *http.Request, op string) (UserTagsRequest, bool) {
+	var req UserTagsRequest
+	if err := httpserver.DecodeJSON(r, &req); err != nil {
+		httpserver.Logger(r).Error(op+": invalid JSON", "err", err)
+		httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid JSON", nil)
+		return req, false
+	}
+	if req.UserID == "" {
+		httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "user_id is required", nil)
+		return req, false
+	}
+	return req, true
+}
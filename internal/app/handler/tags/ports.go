@@ -0,0 +1,10 @@
+package tags
+
+import "context"
+
+type Service interface {
+	ListTags(ctx context.Context, userID string) ([]string, error)
+	AddTags(ctx context.Context, userID string, tags []string) ([]string, error)
+	SetTags(ctx context.Context, userID string, tags []string) ([]string, error)
+	RemoveTag(ctx context.Context, userID, tag string) ([]string, error)
+}
@@ -1,8 +1,11 @@
 package teams
 
 type Team struct {
-	TeamName string       `json:"team_name"`
-	Members  []TeamMember `json:"members"`
+	TeamName          string       `json:"team_name"`
+	RequiredReviewers int          `json:"required_reviewers"`
+	SaturationPolicy  string       `json:"saturation_policy"`
+	FallbackTeams     []string     `json:"fallback_teams"`
+	Members           []TeamMember `json:"members"`
 }
 
 type TeamMember struct {
@@ -11,11 +14,47 @@ type TeamMember struct {
 	IsActive bool   `json:"is_active"`
 }
 
+type ListTeamsResponse struct {
+	Teams      []Team `json:"teams"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
 type CreateTeamRequest struct {
-	TeamName string       `json:"team_name"`
-	Members  []TeamMember `json:"members"`
+	TeamName          string       `json:"team_name"`
+	RequiredReviewers *int         `json:"required_reviewers,omitempty"`
+	SaturationPolicy  *string      `json:"saturation_policy,omitempty"`
+	Members           []TeamMember `json:"members"`
 }
 
 type CreateTeamResponse struct {
 	Team Team `json:"team"`
 }
+
+type SetRequiredReviewersRequest struct {
+	TeamName          string `json:"team_name"`
+	RequiredReviewers int    `json:"required_reviewers"`
+}
+
+type SetRequiredReviewersResponse struct {
+	Team Team `json:"team"`
+}
+
+type SetSaturationPolicyRequest struct {
+	TeamName         string `json:"team_name"`
+	SaturationPolicy string `json:"saturation_policy"`
+}
+
+type SetSaturationPolicyResponse struct {
+	Team Team `json:"team"`
+}
+
+// SetFallbackTeamsRequest lists, in order of preference, the teams that lend
+// reviewers to team_name; an empty list removes them.
+type SetFallbackTeamsRequest struct {
+	TeamName      string   `json:"team_name"`
+	FallbackTeams []string `json:"fallback_teams"`
+}
+
+type SetFallbackTeamsResponse struct {
+	Team Team `json:"team"`
+}
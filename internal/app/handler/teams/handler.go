@@ -4,21 +4,19 @@ import (
 	"errors"
 	"net/http"
 
-	"log/slog"
-
 	"github.com/user/reviewer-svc/internal/app/httpserver"
 	"github.com/user/reviewer-svc/internal/app/handler/users"
 	"github.com/user/reviewer-svc/internal/domain"
+	domainteam "github.com/user/reviewer-svc/internal/domain/team"
 )
 
 type Handler struct {
 	service Service
 	users   users.Service
-	log     *slog.Logger
 }
 
-func NewHandler(service Service, usersSvc users.Service, log *slog.Logger) *Handler {
-	return &Handler{service: service, users: usersSvc, log: log}
+func NewHandler(service Service, usersSvc users.Service) *Handler {
+	return &Handler{service: service, users: usersSvc}
 }
 
 // @Summary     Create team
@@ -32,65 +30,78 @@ func NewHandler(service Service, usersSvc users.Service, log *slog.Logger) *Hand
 func (h *Handler) CreateTeam(w http.ResponseWriter, r *http.Request) {
 		var req CreateTeamRequest
 		if err := httpserver.DecodeJSON(r, &req); err != nil {
-			h.log.Error("create team: invalid JSON", "err", err)
+			httpserver.Logger(r).Error("create team: invalid JSON", "err", err)
 			httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid JSON", nil)
 			return
 		}
 	
-		team, err := h.service.CreateTeam(r.Context(), req.TeamName)
+		requiredReviewers := domainteam.DefaultRequiredReviewers
+		if req.RequiredReviewers != nil {
+			requiredReviewers = *req.RequiredReviewers
+		}
+		policy := domainteam.DefaultSaturationPolicy
+		if req.SaturationPolicy != nil {
+			policy = domainteam.SaturationPolicy(*req.SaturationPolicy)
+		}
+
+		team, err := h.service.CreateTeam(r.Context(), req.TeamName, requiredReviewers, policy)
 		if err != nil {
 			if errors.Is(err, domain.ErrAlreadyExists) {
-				h.log.Error("create team failed", "err", err, "code", "TEAM_EXISTS")
+				httpserver.Logger(r).Error("create team failed", "err", err, "code", "TEAM_EXISTS")
 				httpserver.WriteError(w, http.StatusBadRequest, "TEAM_EXISTS", "team_name already exists", nil)
 				return
 			}
-			status, code := httpserver.MapError(err)
-			h.log.Error("create team failed", "err", err, "code", code)
-			httpserver.WriteError(w, status, code, err.Error(), nil)
+			httpserver.WriteDomainError(w, r, "create team failed", err)
 			return
 		}
 
 		for _, m := range req.Members {
 			_, err := h.users.UpsertUserByID(r.Context(), m.UserID, team.ID, m.Username, m.IsActive)
 			if err != nil {
-				status, code := httpserver.MapError(err)
-				h.log.Error("create team: upsert user failed", "err", err, "code", code)
-				httpserver.WriteError(w, status, code, err.Error(), nil)
+				httpserver.WriteDomainError(w, r, "create team: upsert user failed", err)
 				return
 			}
 		}
 
-		usersList, err := h.users.ListUsers(r.Context(), &team.ID, nil)
+		members, err := h.users.ListUsers(r.Context(), &team.ID, nil, domain.PageRequest{})
 		if err != nil {
-			status, code := httpserver.MapError(err)
-			h.log.Error("create team: list users failed", "err", err, "code", code)
-			httpserver.WriteError(w, status, code, err.Error(), nil)
+			httpserver.WriteDomainError(w, r, "create team: list users failed", err)
 			return
 		}
 	
-	respTeam := withMembers(toResponse(*team), usersList)
+	respTeam := withMembers(toResponse(*team), members.Items)
 	httpserver.WriteJSON(w, http.StatusCreated, CreateTeamResponse{Team: respTeam})
 }
 
 // @Summary     List teams
 // @Tags        teams
 // @Produce     json
-// @Success     200     {array}   Team
+// @Param       limit   query     int     false  "Page size"
+// @Param       cursor  query     string  false  "next_cursor of the previous page"
+// @Success     200     {object}  ListTeamsResponse
+// @Failure     400     {object}  httpserver.ErrorResponse
 // @Router      /teams [get]
 func (h *Handler) ListTeams(w http.ResponseWriter, r *http.Request) {
-		teams, err := h.service.ListTeams(r.Context())
+		page, err := httpserver.ParsePageRequest(r)
 		if err != nil {
-			status, code := httpserver.MapError(err)
-		h.log.Error("list teams failed", "err", err, "code", code)
-		httpserver.WriteError(w, status, code, err.Error(), nil)
+			httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+			return
+		}
+
+		teams, err := h.service.ListTeams(r.Context(), page)
+		if err != nil {
+			httpserver.WriteDomainError(w, r, "list teams failed", err)
 		return
 	}
 
-	res := make([]Team, 0, len(teams))
-	for _, t := range teams {
+	res := make([]Team, 0, len(teams.Items))
+	for _, t := range teams.Items {
 		res = append(res, toResponse(t))
 	}
-	httpserver.WriteJSON(w, http.StatusOK, res)
+	httpserver.WriteJSON(w, http.StatusOK, ListTeamsResponse{
+		Teams:      res,
+		NextCursor: httpserver.EncodeCursor(teams.NextCursor),
+	})
 }
 
 // @Summary     Get team by name
@@ -108,35 +119,159 @@ func (h *Handler) GetTeam(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		teams, err := h.service.ListTeams(r.Context())
+		team, err := h.service.GetTeamByName(r.Context(), teamName)
 		if err != nil {
-			status, code := httpserver.MapError(err)
-			h.log.Error("get team failed", "err", err, "code", code)
-			httpserver.WriteError(w, status, code, err.Error(), nil)
+			if errors.Is(err, domain.ErrNotFound) {
+				httpserver.WriteError(w, http.StatusNotFound, "NOT_FOUND", "team not found", nil)
+				return
+			}
+			httpserver.WriteDomainError(w, r, "get team failed", err)
 			return
 		}
-	
-		var found *Team
-		for _, t := range teams {
-			if t.Name == teamName {
-				base := toResponse(t)
-				usersList, uerr := h.users.ListUsers(r.Context(), &t.ID, nil)
-				if uerr != nil {
-					status, code := httpserver.MapError(uerr)
-					h.log.Error("get team: list users failed", "err", uerr, "code", code)
-					httpserver.WriteError(w, status, code, uerr.Error(), nil)
-					return
-				}
-				resp := withMembers(base, usersList)
-				found = &resp
-				break
-			}
+
+		members, err := h.users.ListUsers(r.Context(), &team.ID, nil, domain.PageRequest{})
+		if err != nil {
+			httpserver.WriteDomainError(w, r, "get team: list users failed", err)
+			return
 		}
 
-		if found == nil {
+		httpserver.WriteJSON(w, http.StatusOK, withMembers(toResponse(*team), members.Items))
+	}
+
+// @Summary     Set number of reviewers required for team PRs
+// @Tags        teams
+// @Accept      json
+// @Produce     json
+// @Param       body    body      SetRequiredReviewersRequest   true  "Team settings"
+// @Success     200     {object}  SetRequiredReviewersResponse
+// @Failure     400     {object}  httpserver.ErrorResponse
+// @Failure     404     {object}  httpserver.ErrorResponse
+// @Router      /team/setRequiredReviewers [post]
+func (h *Handler) SetRequiredReviewers(w http.ResponseWriter, r *http.Request) {
+	var req SetRequiredReviewersRequest
+	if err := httpserver.DecodeJSON(r, &req); err != nil {
+		httpserver.Logger(r).Error("set required reviewers: invalid JSON", "err", err)
+		httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid JSON", nil)
+		return
+	}
+	if req.TeamName == "" {
+		httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "team_name is required", nil)
+		return
+	}
+
+	found, err := h.service.GetTeamByName(r.Context(), req.TeamName)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
 			httpserver.WriteError(w, http.StatusNotFound, "NOT_FOUND", "team not found", nil)
 			return
 		}
+		httpserver.WriteDomainError(w, r, "set required reviewers: get team failed", err)
+		return
+	}
+
+	team, err := h.service.SetRequiredReviewers(r.Context(), found.ID, req.RequiredReviewers)
+	if err != nil {
+		httpserver.WriteDomainError(w, r, "set required reviewers failed", err)
+		return
+	}
 
-		httpserver.WriteJSON(w, http.StatusOK, *found)
+	members, err := h.users.ListUsers(r.Context(), &team.ID, nil, domain.PageRequest{})
+	if err != nil {
+		httpserver.WriteDomainError(w, r, "set required reviewers: list users failed", err)
+		return
 	}
+
+	httpserver.WriteJSON(w, http.StatusOK, SetRequiredReviewersResponse{Team: withMembers(toResponse(*team), members.Items)})
+}
+
+// @Summary     Set what happens when every team reviewer is at capacity
+// @Tags        teams
+// @Accept      json
+// @Produce     json
+// @Param       body    body      SetSaturationPolicyRequest   true  "Team settings"
+// @Success     200     {object}  SetSaturationPolicyResponse
+// @Failure     400     {object}  httpserver.ErrorResponse
+// @Failure     404     {object}  httpserver.ErrorResponse
+// @Router      /team/setSaturationPolicy [post]
+func (h *Handler) SetSaturationPolicy(w http.ResponseWriter, r *http.Request) {
+	var req SetSaturationPolicyRequest
+	if err := httpserver.DecodeJSON(r, &req); err != nil {
+		httpserver.Logger(r).Error("set saturation policy: invalid JSON", "err", err)
+		httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid JSON", nil)
+		return
+	}
+	if req.TeamName == "" {
+		httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "team_name is required", nil)
+		return
+	}
+
+	found, err := h.service.GetTeamByName(r.Context(), req.TeamName)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			httpserver.WriteError(w, http.StatusNotFound, "NOT_FOUND", "team not found", nil)
+			return
+		}
+		httpserver.WriteDomainError(w, r, "set saturation policy: get team failed", err)
+		return
+	}
+
+	team, err := h.service.SetSaturationPolicy(r.Context(), found.ID, domainteam.SaturationPolicy(req.SaturationPolicy))
+	if err != nil {
+		httpserver.WriteDomainError(w, r, "set saturation policy failed", err)
+		return
+	}
+
+	members, err := h.users.ListUsers(r.Context(), &team.ID, nil, domain.PageRequest{})
+	if err != nil {
+		httpserver.WriteDomainError(w, r, "set saturation policy: list users failed", err)
+		return
+	}
+
+	httpserver.WriteJSON(w, http.StatusOK, SetSaturationPolicyResponse{Team: withMembers(toResponse(*team), members.Items)})
+}
+
+// @Summary     Set teams that lend reviewers when the team cannot fill its slots
+// @Tags        teams
+// @Accept      json
+// @Produce     json
+// @Param       body    body      SetFallbackTeamsRequest   true  "Team settings"
+// @Success     200     {object}  SetFallbackTeamsResponse
+// @Failure     400     {object}  httpserver.ErrorResponse
+// @Failure     404     {object}  httpserver.ErrorResponse
+// @Router      /team/setFallbackTeams [post]
+func (h *Handler) SetFallbackTeams(w http.ResponseWriter, r *http.Request) {
+	var req SetFallbackTeamsRequest
+	if err := httpserver.DecodeJSON(r, &req); err != nil {
+		httpserver.Logger(r).Error("set fallback teams: invalid JSON", "err", err)
+		httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid JSON", nil)
+		return
+	}
+	if req.TeamName == "" {
+		httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "team_name is required", nil)
+		return
+	}
+
+	found, err := h.service.GetTeamByName(r.Context(), req.TeamName)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			httpserver.WriteError(w, http.StatusNotFound, "NOT_FOUND", "team not found", nil)
+			return
+		}
+		httpserver.WriteDomainError(w, r, "set fallback teams: get team failed", err)
+		return
+	}
+
+	team, err := h.service.SetFallbackTeams(r.Context(), found.ID, req.FallbackTeams)
+	if err != nil {
+		httpserver.WriteDomainError(w, r, "set fallback teams failed", err)
+		return
+	}
+
+	members, err := h.users.ListUsers(r.Context(), &team.ID, nil, domain.PageRequest{})
+	if err != nil {
+		httpserver.WriteDomainError(w, r, "set fallback teams: list users failed", err)
+		return
+	}
+
+	httpserver.WriteJSON(w, http.StatusOK, SetFallbackTeamsResponse{Team: withMembers(toResponse(*team), members.Items)})
+}
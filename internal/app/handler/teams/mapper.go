@@ -6,9 +6,16 @@ import (
 )
 
 func toResponse(t team.Team) Team {
+	fallbacks := make([]string, 0, len(t.FallbackTeams))
+	for _, f := range t.FallbackTeams {
+		fallbacks = append(fallbacks, f.Name)
+	}
 	return Team{
-		TeamName: t.Name,
-		Members:  nil,
+		TeamName:          t.Name,
+		RequiredReviewers: t.RequiredReviewers,
+		SaturationPolicy:  string(t.SaturationPolicy),
+		FallbackTeams:     fallbacks,
+		Members:           nil,
 	}
 }
 
@@ -3,11 +3,16 @@ package teams
 import (
 	"context"
 
+	"github.com/user/reviewer-svc/internal/domain"
 	"github.com/user/reviewer-svc/internal/domain/team"
 )
 
 type Service interface {
-	CreateTeam(ctx context.Context, name string) (*team.Team, error)
-	ListTeams(ctx context.Context) ([]team.Team, error)
+	CreateTeam(ctx context.Context, name string, requiredReviewers int, policy team.SaturationPolicy) (*team.Team, error)
+	ListTeams(ctx context.Context, page domain.PageRequest) (domain.Page[team.Team], error)
 	GetTeam(ctx context.Context, id string) (*team.Team, error)
+	GetTeamByName(ctx context.Context, name string) (*team.Team, error)
+	SetRequiredReviewers(ctx context.Context, id string, requiredReviewers int) (*team.Team, error)
+	SetSaturationPolicy(ctx context.Context, id string, policy team.SaturationPolicy) (*team.Team, error)
+	SetFallbackTeams(ctx context.Context, id string, names []string) (*team.Team, error)
 }
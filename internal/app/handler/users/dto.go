@@ -5,6 +5,13 @@ type User struct {
 	Username string `json:"username"`
 	TeamName string `json:"team_name"`
 	IsActive bool   `json:"is_active"`
+	// MaxOpenReviews is omitted for users without a review limit.
+	MaxOpenReviews *int `json:"max_open_reviews,omitempty"`
+}
+
+type ListUsersResponse struct {
+	Users      []User `json:"users"`
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 type SetIsActiveRequest struct {
@@ -16,6 +23,15 @@ type SetIsActiveResponse struct {
 	User User `json:"user"`
 }
 
+type SetMaxOpenReviewsRequest struct {
+	UserID         string `json:"user_id"`
+	MaxOpenReviews *int   `json:"max_open_reviews"`
+}
+
+type SetMaxOpenReviewsResponse struct {
+	User User `json:"user"`
+}
+
 type CreateUserRequest struct {
 	Name     string `json:"name"`
 	IsActive *bool  `json:"isActive,omitempty"`
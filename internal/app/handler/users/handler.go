@@ -4,8 +4,6 @@ import (
 	"net/http"
 	"strconv"
 
-	"log/slog"
-
 	chi "github.com/go-chi/chi/v5"
 
 	"github.com/user/reviewer-svc/internal/app/httpserver"
@@ -15,11 +13,10 @@ type Handler struct {
 	users Service
 	bulk  BulkService
 	teams TeamService
-	log   *slog.Logger
 }
 
-func NewHandler(users Service, bulk BulkService, teams TeamService, log *slog.Logger) *Handler {
-	return &Handler{users: users, bulk: bulk, teams: teams, log: log}
+func NewHandler(users Service, bulk BulkService, teams TeamService) *Handler {
+	return &Handler{users: users, bulk: bulk, teams: teams}
 }
 
 // @Summary     Set user active status
@@ -34,7 +31,7 @@ func NewHandler(users Service, bulk BulkService, teams TeamService, log *slog.Lo
 func (h *Handler) SetIsActive(w http.ResponseWriter, r *http.Request) {
 	var req SetIsActiveRequest
 	if err := httpserver.DecodeJSON(r, &req); err != nil {
-		h.log.Error("set is_active: invalid JSON", "err", err)
+		httpserver.Logger(r).Error("set is_active: invalid JSON", "err", err)
 		httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid JSON", nil)
 		return
 	}
@@ -44,23 +41,55 @@ func (h *Handler) SetIsActive(w http.ResponseWriter, r *http.Request) {
 	isActive := req.IsActive
 	user, err := h.users.UpdateUser(r.Context(), userID, nil, &isActive)
 	if err != nil {
-		status, code := httpserver.MapError(err)
-		h.log.Error("set is_active failed", "err", err, "code", code)
-		httpserver.WriteError(w, status, code, err.Error(), nil)
+		httpserver.WriteDomainError(w, r, "set is_active failed", err)
 		return
 	}
 
 	team, err := h.teams.GetTeam(r.Context(), user.TeamID)
 	if err != nil {
-		status, code := httpserver.MapError(err)
-		h.log.Error("get team failed", "err", err, "code", code)
-		httpserver.WriteError(w, status, code, err.Error(), nil)
+		httpserver.WriteDomainError(w, r, "get team failed", err)
 		return
 	}
 
 	httpserver.WriteJSON(w, http.StatusOK, SetIsActiveResponse{User: toResponseWithTeam(*user, *team)})
 }
 
+// @Summary     Limit how many open PRs the user reviews at once
+// @Tags        users
+// @Accept      json
+// @Produce     json
+// @Param       body    body      SetMaxOpenReviewsRequest   true  "Review limit; null removes it"
+// @Success     200     {object}  SetMaxOpenReviewsResponse
+// @Failure     400     {object}  httpserver.ErrorResponse
+// @Failure     404     {object}  httpserver.ErrorResponse
+// @Router      /users/setMaxOpenReviews [post]
+func (h *Handler) SetMaxOpenReviews(w http.ResponseWriter, r *http.Request) {
+	var req SetMaxOpenReviewsRequest
+	if err := httpserver.DecodeJSON(r, &req); err != nil {
+		httpserver.Logger(r).Error("set max_open_reviews: invalid JSON", "err", err)
+		httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid JSON", nil)
+		return
+	}
+	if req.UserID == "" {
+		httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "user_id is required", nil)
+		return
+	}
+
+	user, err := h.users.SetMaxOpenReviews(r.Context(), req.UserID, req.MaxOpenReviews)
+	if err != nil {
+		httpserver.WriteDomainError(w, r, "set max_open_reviews failed", err)
+		return
+	}
+
+	team, err := h.teams.GetTeam(r.Context(), user.TeamID)
+	if err != nil {
+		httpserver.WriteDomainError(w, r, "get team failed", err)
+		return
+	}
+
+	httpserver.WriteJSON(w, http.StatusOK, SetMaxOpenReviewsResponse{User: toResponseWithTeam(*user, *team)})
+}
+
 
 // @Summary     Create user in team
 // @Tags        users
@@ -77,7 +106,7 @@ func (h *Handler) CreateUser(w http.ResponseWriter, r *http.Request) {
 
 	var req CreateUserRequest
 	if err := httpserver.DecodeJSON(r, &req); err != nil {
-		h.log.Error("create user: invalid JSON", "err", err)
+		httpserver.Logger(r).Error("create user: invalid JSON", "err", err)
 		httpserver.WriteError(w, http.StatusBadRequest, "bad_request", "invalid JSON", nil)
 		return
 	}
@@ -89,9 +118,7 @@ func (h *Handler) CreateUser(w http.ResponseWriter, r *http.Request) {
 
 	user, err := h.users.CreateUser(r.Context(), teamID, req.Name, isActive)
 	if err != nil {
-		status, code := httpserver.MapError(err)
-		h.log.Error("create user failed", "err", err, "code", code)
-		httpserver.WriteError(w, status, code, err.Error(), nil)
+		httpserver.WriteDomainError(w, r, "create user failed", err)
 		return
 	}
 
@@ -104,7 +131,10 @@ func (h *Handler) CreateUser(w http.ResponseWriter, r *http.Request) {
 // @Produce     json
 // @Param       teamId   query     string  false  "Team ID"
 // @Param       isActive query     bool    false  "Filter by active flag"
-// @Success     200      {array}   User
+// @Param       limit    query     int     false  "Page size"
+// @Param       cursor   query     string  false  "next_cursor of the previous page"
+// @Success     200      {object}  ListUsersResponse
+// @Failure     400      {object}  httpserver.ErrorResponse
 // @Router      /users [get]
 func (h *Handler) ListUsers(w http.ResponseWriter, r *http.Request) {
 	q := r.URL.Query()
@@ -124,19 +154,26 @@ func (h *Handler) ListUsers(w http.ResponseWriter, r *http.Request) {
 		isActive = &b
 	}
 
-	users, err := h.users.ListUsers(r.Context(), teamID, isActive)
+	page, err := httpserver.ParsePageRequest(r)
+	if err != nil {
+		httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+		return
+	}
+
+	users, err := h.users.ListUsers(r.Context(), teamID, isActive, page)
 	if err != nil {
-		status, code := httpserver.MapError(err)
-		h.log.Error("list users failed", "err", err, "code", code)
-		httpserver.WriteError(w, status, code, err.Error(), nil)
+		httpserver.WriteDomainError(w, r, "list users failed", err)
 		return
 	}
 
-	res := make([]User, 0, len(users))
-	for _, u := range users {
+	res := make([]User, 0, len(users.Items))
+	for _, u := range users.Items {
 		res = append(res, toResponseSimple(u))
 	}
-	httpserver.WriteJSON(w, http.StatusOK, res)
+	httpserver.WriteJSON(w, http.StatusOK, ListUsersResponse{
+		Users:      res,
+		NextCursor: httpserver.EncodeCursor(users.NextCursor),
+	})
 }
 
 
@@ -153,9 +190,7 @@ func (h *Handler) GetUser(w http.ResponseWriter, r *http.Request) {
 
 	user, err := h.users.GetUser(r.Context(), id)
 	if err != nil {
-		status, code := httpserver.MapError(err)
-		h.log.Error("get user failed", "err", err, "code", code)
-		httpserver.WriteError(w, status, code, err.Error(), nil)
+		httpserver.WriteDomainError(w, r, "get user failed", err)
 		return
 	}
 
@@ -179,16 +214,14 @@ func (h *Handler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 
 	var req UpdateUserRequest
 	if err := httpserver.DecodeJSON(r, &req); err != nil {
-		h.log.Error("update user: invalid JSON", "err", err)
+		httpserver.Logger(r).Error("update user: invalid JSON", "err", err)
 		httpserver.WriteError(w, http.StatusBadRequest, "bad_request", "invalid JSON", nil)
 		return
 	}
 
 	user, err := h.users.UpdateUser(r.Context(), id, req.Name, req.IsActive)
 	if err != nil {
-		status, code := httpserver.MapError(err)
-		h.log.Error("update user failed", "err", err, "code", code)
-		httpserver.WriteError(w, status, code, err.Error(), nil)
+		httpserver.WriteDomainError(w, r, "update user failed", err)
 		return
 	}
 
@@ -212,7 +245,7 @@ func (h *Handler) BulkDeactivateUsers(w http.ResponseWriter, r *http.Request) {
 
 	var req BulkDeactivateUsersRequest
 	if err := httpserver.DecodeJSON(r, &req); err != nil {
-		h.log.Error("bulk deactivate: invalid JSON", "err", err)
+		httpserver.Logger(r).Error("bulk deactivate: invalid JSON", "err", err)
 		httpserver.WriteError(w, http.StatusBadRequest, "bad_request", "invalid JSON", nil)
 		return
 	}
@@ -224,9 +257,7 @@ func (h *Handler) BulkDeactivateUsers(w http.ResponseWriter, r *http.Request) {
 
 	deactivated, reassigned, err := h.bulk.BulkDeactivate(r.Context(), teamID, req.UserIDs)
 	if err != nil {
-		status, code := httpserver.MapError(err)
-		h.log.Error("bulk deactivate failed", "err", err, "code", code)
-		httpserver.WriteError(w, status, code, err.Error(), nil)
+		httpserver.WriteDomainError(w, r, "bulk deactivate failed", err)
 		return
 	}
 
@@ -7,10 +7,11 @@ import (
 
 func toResponse(u user.User, teamName string) User {
 	return User{
-		UserID:   u.ID,
-		Username: u.Name,
-		TeamName: teamName,
-		IsActive: u.IsActive,
+		UserID:         u.ID,
+		Username:       u.Name,
+		TeamName:       teamName,
+		IsActive:       u.IsActive,
+		MaxOpenReviews: u.MaxOpenReviews,
 	}
 }
 
@@ -20,9 +21,10 @@ func toResponseWithTeam(u user.User, t team.Team) User {
 
 func toResponseSimple(u user.User) User {
 	return User{
-		UserID:   u.ID,
-		Username: u.Name,
-		TeamName: "",
-		IsActive: u.IsActive,
+		UserID:         u.ID,
+		Username:       u.Name,
+		TeamName:       "",
+		IsActive:       u.IsActive,
+		MaxOpenReviews: u.MaxOpenReviews,
 	}
 }
@@ -3,6 +3,7 @@ package users
 import (
 	"context"
 
+	"github.com/user/reviewer-svc/internal/domain"
 	domainteam "github.com/user/reviewer-svc/internal/domain/team"
 	domainuser "github.com/user/reviewer-svc/internal/domain/user"
 )
@@ -10,9 +11,10 @@ import (
 type Service interface {
 	CreateUser(ctx context.Context, teamID string, name string, isActive bool) (*domainuser.User, error)
 	UpsertUserByID(ctx context.Context, userID string, teamID string, name string, isActive bool) (*domainuser.User, error)
-	ListUsers(ctx context.Context, teamID *string, isActive *bool) ([]domainuser.User, error)
+	ListUsers(ctx context.Context, teamID *string, isActive *bool, page domain.PageRequest) (domain.Page[domainuser.User], error)
 	GetUser(ctx context.Context, id string) (*domainuser.User, error)
 	UpdateUser(ctx context.Context, id string, name *string, isActive *bool) (*domainuser.User, error)
+	SetMaxOpenReviews(ctx context.Context, id string, max *int) (*domainuser.User, error)
 }
 
 type BulkService interface {
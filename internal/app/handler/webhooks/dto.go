@@ -0,0 +1,39 @@
+package webhooks
+
+type Subscription struct {
+	ID        string   `json:"id"`
+	URL       string   `json:"url"`
+	Events    []string `json:"events"`
+	IsActive  bool     `json:"is_active"`
+	CreatedAt string   `json:"createdAt"`
+}
+
+type CreateSubscriptionRequest struct {
+	URL    string   `json:"url"`
+	Secret string   `json:"secret"`
+	Events []string `json:"events"`
+}
+
+type CreateSubscriptionResponse struct {
+	Subscription Subscription `json:"subscription"`
+}
+
+type ListSubscriptionsResponse struct {
+	Items []Subscription `json:"items"`
+}
+
+type DeadLetter struct {
+	DeliveryID     int64   `json:"delivery_id"`
+	SubscriptionID string  `json:"subscription_id"`
+	URL            string  `json:"url"`
+	EventID        int64   `json:"event_id"`
+	EventType      string  `json:"event_type"`
+	Payload        any     `json:"payload"`
+	OccurredAt     string  `json:"occurred_at"`
+	Attempts       int     `json:"attempts"`
+	LastError      *string `json:"last_error,omitempty"`
+}
+
+type ListDeadLettersResponse struct {
+	Items []DeadLetter `json:"items"`
+}
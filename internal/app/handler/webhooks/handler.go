@@ -0,0 +1,120 @@
+package webhooks
+
+import (
+	"net/http"
+	"strconv"
+
+	chi "github.com/go-chi/chi/v5"
+
+	"github.com/user/reviewer-svc/internal/app/httpserver"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// @Summary     Register webhook subscriber
+// @Tags        webhooks
+// @Accept      json
+// @Produce     json
+// @Param       body  body      CreateSubscriptionRequest  true  "Subscription payload"
+// @Success     201   {object}  CreateSubscriptionResponse
+// @Failure     400   {object}  httpserver.ErrorResponse
+// @Router      /webhooks/subscriptions [post]
+func (h *Handler) CreateSubscription(w http.ResponseWriter, r *http.Request) {
+	var req CreateSubscriptionRequest
+	if err := httpserver.DecodeJSON(r, &req); err != nil {
+		httpserver.Logger(r).Error("create webhook subscription: invalid JSON", "err", err)
+		httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid JSON", nil)
+		return
+	}
+
+	sub, err := h.service.CreateSubscription(r.Context(), req.URL, req.Secret, toEventTypes(req.Events))
+	if err != nil {
+		httpserver.WriteDomainError(w, r, "create webhook subscription failed", err)
+		return
+	}
+
+	httpserver.WriteJSON(w, http.StatusCreated, CreateSubscriptionResponse{Subscription: toResponse(*sub)})
+}
+
+// @Summary     List webhook subscribers
+// @Tags        webhooks
+// @Produce     json
+// @Success     200  {object}  ListSubscriptionsResponse
+// @Router      /webhooks/subscriptions [get]
+func (h *Handler) ListSubscriptions(w http.ResponseWriter, r *http.Request) {
+	subs, err := h.service.ListSubscriptions(r.Context())
+	if err != nil {
+		httpserver.WriteDomainError(w, r, "list webhook subscriptions failed", err)
+		return
+	}
+
+	res := ListSubscriptionsResponse{Items: make([]Subscription, 0, len(subs))}
+	for _, s := range subs {
+		res.Items = append(res.Items, toResponse(s))
+	}
+	httpserver.WriteJSON(w, http.StatusOK, res)
+}
+
+// @Summary     Remove webhook subscriber
+// @Tags        webhooks
+// @Param       subscriptionId  path  string  true  "Subscription ID"
+// @Success     204
+// @Failure     404  {object}  httpserver.ErrorResponse
+// @Router      /webhooks/subscriptions/{subscriptionId} [delete]
+func (h *Handler) DeleteSubscription(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "subscriptionId")
+
+	if err := h.service.DeleteSubscription(r.Context(), id); err != nil {
+		httpserver.WriteDomainError(w, r, "delete webhook subscription failed", err)
+		return
+	}
+
+	httpserver.WriteNoContent(w)
+}
+
+// @Summary     List deliveries that exhausted their retries
+// @Tags        webhooks
+// @Produce     json
+// @Success     200  {object}  ListDeadLettersResponse
+// @Router      /webhooks/deadLetters [get]
+func (h *Handler) ListDeadLetters(w http.ResponseWriter, r *http.Request) {
+	list, err := h.service.ListDeadLetters(r.Context())
+	if err != nil {
+		httpserver.WriteDomainError(w, r, "list webhook dead letters failed", err)
+		return
+	}
+
+	res := ListDeadLettersResponse{Items: make([]DeadLetter, 0, len(list))}
+	for _, d := range list {
+		res.Items = append(res.Items, toDeadLetterResponse(d))
+	}
+	httpserver.WriteJSON(w, http.StatusOK, res)
+}
+
+// @Summary     Requeue dead-lettered delivery
+// @Tags        webhooks
+// @Param       deliveryId  path  int  true  "Delivery ID"
+// @Success     204
+// @Failure     400  {object}  httpserver.ErrorResponse
+// @Failure     404  {object}  httpserver.ErrorResponse
+// @Router      /webhooks/deadLetters/{deliveryId}/retry [post]
+func (h *Handler) RetryDeadLetter(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "deliveryId"), 10, 64)
+	if err != nil {
+		httpserver.WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid deliveryId", nil)
+		return
+	}
+
+	if err := h.service.RetryDeadLetter(r.Context(), id); err != nil {
+		httpserver.WriteDomainError(w, r, "retry webhook dead letter failed", err)
+		return
+	}
+
+	httpserver.WriteNoContent(w)
+}
@@ -0,0 +1,43 @@
+package webhooks
+
+import (
+	"encoding/json"
+
+	domainwebhook "github.com/user/reviewer-svc/internal/domain/webhook"
+)
+
+func toResponse(s domainwebhook.Subscription) Subscription {
+	events := make([]string, 0, len(s.Events))
+	for _, e := range s.Events {
+		events = append(events, string(e))
+	}
+	return Subscription{
+		ID:        s.ID,
+		URL:       s.URL,
+		Events:    events,
+		IsActive:  s.IsActive,
+		CreatedAt: s.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+func toDeadLetterResponse(d domainwebhook.Delivery) DeadLetter {
+	return DeadLetter{
+		DeliveryID:     d.ID,
+		SubscriptionID: d.SubscriptionID,
+		URL:            d.URL,
+		EventID:        d.EventID,
+		EventType:      string(d.EventType),
+		Payload:        json.RawMessage(d.Payload),
+		OccurredAt:     d.OccurredAt.Format("2006-01-02T15:04:05Z07:00"),
+		Attempts:       d.Attempts,
+		LastError:      d.LastError,
+	}
+}
+
+func toEventTypes(events []string) []domainwebhook.EventType {
+	res := make([]domainwebhook.EventType, 0, len(events))
+	for _, e := range events {
+		res = append(res, domainwebhook.EventType(e))
+	}
+	return res
+}
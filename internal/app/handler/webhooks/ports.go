@@ -0,0 +1,15 @@
+package webhooks
+
+import (
+	"context"
+
+	domainwebhook "github.com/user/reviewer-svc/internal/domain/webhook"
+)
+
+type Service interface {
+	CreateSubscription(ctx context.Context, url, secret string, events []domainwebhook.EventType) (*domainwebhook.Subscription, error)
+	ListSubscriptions(ctx context.Context) ([]domainwebhook.Subscription, error)
+	DeleteSubscription(ctx context.Context, id string) error
+	ListDeadLetters(ctx context.Context) ([]domainwebhook.Delivery, error)
+	RetryDeadLetter(ctx context.Context, id int64) error
+}
@@ -0,0 +1,147 @@
+package httpserver
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	chi "github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/user/reviewer-svc/internal/domain"
+)
+
+// Logger returns the request-scoped logger installed by RequestLogger.
+func Logger(r *http.Request) *slog.Logger {
+	return domain.LoggerFromContext(r.Context())
+}
+
+// WriteDomainError maps err to its status and code, logs it with the request
+// logger and writes the error response. args are extra log attributes.
+func WriteDomainError(w http.ResponseWriter, r *http.Request, msg string, err error, args ...any) {
+	status, code := MapError(err)
+	level := slog.LevelWarn
+	if status >= http.StatusInternalServerError {
+		level = slog.LevelError
+	}
+	Logger(r).Log(r.Context(), level, msg, append([]any{"err", err, "code", code}, args...)...)
+	WriteError(w, status, code, err.Error(), nil)
+}
+
+// RequestLogger puts a logger tagged with the request ID, method, route
+// pattern and caller (see SetCaller) into the request context and writes
+// one access log line per request. Successful reads are frequent and dull, so only one in
+// sampleReads of them is logged; values below 2 log every request.
+func RequestLogger(base *slog.Logger, sampleReads int) func(http.Handler) http.Handler {
+	var reads atomic.Uint64
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			scope := &requestScope{rctx: chi.RouteContext(r.Context())}
+
+			attrs := []any{"method", r.Method}
+			if id := middleware.GetReqID(r.Context()); id != "" {
+				attrs = append(attrs, "request_id", id)
+			}
+			log := slog.New(scopeHandler{ungrouped: base.Handler(), scope: scope}).With(attrs...)
+
+			ctx := domain.WithLogger(r.Context(), log)
+			ctx = context.WithValue(ctx, requestScopeKey{}, scope)
+
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			isRead := r.Method == http.MethodGet || r.Method == http.MethodHead
+			if isRead && status < http.StatusBadRequest && sampleReads > 1 && reads.Add(1)%uint64(sampleReads) != 1 {
+				return
+			}
+
+			level := slog.LevelInfo
+			if status >= http.StatusInternalServerError {
+				level = slog.LevelError
+			}
+			log.Log(ctx, level, "request completed",
+				"path", r.URL.Path,
+				"status", status,
+				"bytes", ww.BytesWritten(),
+				"duration_ms", time.Since(start).Milliseconds(),
+			)
+		})
+	}
+}
+
+// requestScope holds what is only known once the request has been routed
+// or authenticated.
+type requestScope struct {
+	rctx   *chi.Context
+	caller string
+}
+
+type requestScopeKey struct{}
+
+// SetCaller names who made r in its log lines from now on and in its access
+// log. Whoever authenticates the request calls it.
+func SetCaller(r *http.Request, caller string) {
+	if scope, ok := r.Context().Value(requestScopeKey{}).(*requestScope); ok {
+		scope.caller = caller
+	}
+}
+
+// scopeHandler adds the route pattern and caller to every record at the
+// time it is logged rather than when the logger is created. They go to the
+// handler as it was before any group was opened, so that they stay
+// top-level attributes however the logger was grouped since.
+type scopeHandler struct {
+	ungrouped slog.Handler
+	// grouped replays the groups opened, and the attributes added within
+	// them, on top of ungrouped.
+	grouped []func(slog.Handler) slog.Handler
+	scope   *requestScope
+}
+
+func (h scopeHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.ungrouped.Enabled(ctx, level)
+}
+
+func (h scopeHandler) Handle(ctx context.Context, rec slog.Record) error {
+	var attrs []slog.Attr
+	if h.scope.rctx != nil {
+		if route := h.scope.rctx.RoutePattern(); route != "" {
+			attrs = append(attrs, slog.String("route", route))
+		}
+	}
+	if h.scope.caller != "" {
+		attrs = append(attrs, slog.String("caller", h.scope.caller))
+	}
+
+	handler := h.ungrouped
+	if len(attrs) > 0 {
+		handler = handler.WithAttrs(attrs)
+	}
+	for _, with := range h.grouped {
+		handler = with(handler)
+	}
+	return handler.Handle(ctx, rec)
+}
+
+func (h scopeHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(h.grouped) == 0 {
+		return scopeHandler{ungrouped: h.ungrouped.WithAttrs(attrs), scope: h.scope}
+	}
+	return h.then(func(next slog.Handler) slog.Handler { return next.WithAttrs(attrs) })
+}
+
+func (h scopeHandler) WithGroup(name string) slog.Handler {
+	return h.then(func(next slog.Handler) slog.Handler { return next.WithGroup(name) })
+}
+
+func (h scopeHandler) then(with func(slog.Handler) slog.Handler) scopeHandler {
+	return scopeHandler{ungrouped: h.ungrouped, grouped: append(slices.Clip(h.grouped), with), scope: h.scope}
+}
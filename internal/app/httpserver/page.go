@@ -0,0 +1,61 @@
+package httpserver
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/user/reviewer-svc/internal/domain"
+)
+
+// ParsePageRequest reads the limit and cursor query parameters shared by all
+// list endpoints. A missing limit falls back to domain.DefaultPageLimit.
+func ParsePageRequest(r *http.Request) (domain.PageRequest, error) {
+	q := r.URL.Query()
+	page := domain.PageRequest{Limit: domain.DefaultPageLimit}
+
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > domain.MaxPageLimit {
+			return domain.PageRequest{}, fmt.Errorf("%w: limit must be between 1 and %d", domain.ErrInvalidRequest, domain.MaxPageLimit)
+		}
+		page.Limit = n
+	}
+
+	if v := q.Get("cursor"); v != "" {
+		c, err := DecodeCursor(v)
+		if err != nil {
+			return domain.PageRequest{}, err
+		}
+		page.Cursor = c
+	}
+	return page, nil
+}
+
+// EncodeCursor renders a cursor as an opaque token; nil yields "".
+func EncodeCursor(c *domain.Cursor) string {
+	if c == nil {
+		return ""
+	}
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func DecodeCursor(s string) (*domain.Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", domain.ErrInvalidRequest)
+	}
+	ts, id, ok := strings.Cut(string(raw), "|")
+	if !ok || id == "" {
+		return nil, fmt.Errorf("%w: malformed cursor", domain.ErrInvalidRequest)
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", domain.ErrInvalidRequest)
+	}
+	return &domain.Cursor{CreatedAt: createdAt, ID: id}, nil
+}
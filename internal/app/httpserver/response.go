@@ -48,12 +48,21 @@ func MapError(err error) (int, string) {
 	if errors.Is(err, domain.ErrNotFound) {
 		return http.StatusNotFound, "NOT_FOUND"
 	}
-	if errors.Is(err, domain.ErrAlreadyExists) {
+	if errors.Is(err, domain.ErrPRExists) {
 		return http.StatusConflict, "PR_EXISTS"
 	}
+	if errors.Is(err, domain.ErrAlreadyExists) {
+		return http.StatusConflict, "ALREADY_EXISTS"
+	}
 	if errors.Is(err, domain.ErrAlreadyMerged) {
 		return http.StatusConflict, "PR_MERGED"
 	}
+	if errors.Is(err, domain.ErrInvalidTransition) {
+		return http.StatusConflict, "INVALID_TRANSITION"
+	}
+	if errors.Is(err, domain.ErrPRNotOpen) {
+		return http.StatusConflict, "PR_NOT_OPEN"
+	}
 	if errors.Is(err, domain.ErrNoCandidate) {
 		return http.StatusConflict, "NO_CANDIDATE"
 	}
@@ -69,6 +78,69 @@ func MapError(err error) (int, string) {
 	if errors.Is(err, domain.ErrInvalidPRTitle) {
 		return http.StatusBadRequest, "INVALID_PR_TITLE"
 	}
+	if errors.Is(err, domain.ErrInvalidRequiredReviewers) {
+		return http.StatusBadRequest, "INVALID_REQUIRED_REVIEWERS"
+	}
+	if errors.Is(err, domain.ErrInvalidWebhookURL) {
+		return http.StatusBadRequest, "INVALID_WEBHOOK_URL"
+	}
+	if errors.Is(err, domain.ErrInvalidWebhookEvent) {
+		return http.StatusBadRequest, "INVALID_WEBHOOK_EVENT"
+	}
+	if errors.Is(err, domain.ErrInvalidSaturationPolicy) {
+		return http.StatusBadRequest, "INVALID_SATURATION_POLICY"
+	}
+	if errors.Is(err, domain.ErrInvalidFallbackTeams) {
+		return http.StatusBadRequest, "INVALID_FALLBACK_TEAMS"
+	}
+	if errors.Is(err, domain.ErrInvalidCodeowners) {
+		return http.StatusBadRequest, "INVALID_CODEOWNERS"
+	}
+	if errors.Is(err, domain.ErrInvalidChangedFiles) {
+		return http.StatusBadRequest, "INVALID_CHANGED_FILES"
+	}
+	if errors.Is(err, domain.ErrInvalidTags) {
+		return http.StatusBadRequest, "INVALID_TAGS"
+	}
+	if errors.Is(err, domain.ErrInvalidMaxOpenReviews) {
+		return http.StatusBadRequest, "INVALID_MAX_OPEN_REVIEWS"
+	}
+	if errors.Is(err, domain.ErrInvalidAbsencePeriod) {
+		return http.StatusBadRequest, "INVALID_ABSENCE_PERIOD"
+	}
+	if errors.Is(err, domain.ErrInvalidVerdict) {
+		return http.StatusBadRequest, "INVALID_VERDICT"
+	}
+	if errors.Is(err, domain.ErrNotEnoughApprovals) {
+		return http.StatusConflict, "NOT_ENOUGH_APPROVALS"
+	}
+	if errors.Is(err, domain.ErrInvalidSignature) {
+		return http.StatusUnauthorized, "INVALID_SIGNATURE"
+	}
+	if errors.Is(err, domain.ErrUnknownExternalUser) {
+		return http.StatusUnprocessableEntity, "UNKNOWN_EXTERNAL_USER"
+	}
+	if errors.Is(err, domain.ErrUnauthenticated) {
+		return http.StatusUnauthorized, "UNAUTHORIZED"
+	}
+	if errors.Is(err, domain.ErrForbidden) {
+		return http.StatusForbidden, "FORBIDDEN"
+	}
+	if errors.Is(err, domain.ErrInvalidKeyName) {
+		return http.StatusBadRequest, "INVALID_KEY_NAME"
+	}
+	if errors.Is(err, domain.ErrInvalidScope) {
+		return http.StatusBadRequest, "INVALID_SCOPE"
+	}
+	if errors.Is(err, domain.ErrInvalidIdempotencyKey) {
+		return http.StatusBadRequest, "INVALID_IDEMPOTENCY_KEY"
+	}
+	if errors.Is(err, domain.ErrIdempotencyKeyReused) {
+		return http.StatusUnprocessableEntity, "IDEMPOTENCY_KEY_REUSED"
+	}
+	if errors.Is(err, domain.ErrIdempotencyInProgress) {
+		return http.StatusConflict, "IDEMPOTENCY_IN_PROGRESS"
+	}
 	if errors.Is(err, domain.ErrEmptyUpdate) {
 		return http.StatusBadRequest, "EMPTY_UPDATE"
 	}
@@ -0,0 +1,158 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/user/reviewer-svc/internal/domain"
+	apikeysvc "github.com/user/reviewer-svc/internal/domain/apikey"
+	codeownerssvc "github.com/user/reviewer-svc/internal/domain/codeowners"
+	idempotencysvc "github.com/user/reviewer-svc/internal/domain/idempotency"
+	integrationsvc "github.com/user/reviewer-svc/internal/domain/integration"
+	prsvc "github.com/user/reviewer-svc/internal/domain/pr"
+	statssvc "github.com/user/reviewer-svc/internal/domain/stats"
+	teamsvc "github.com/user/reviewer-svc/internal/domain/team"
+	usersvc "github.com/user/reviewer-svc/internal/domain/user"
+	userreassign "github.com/user/reviewer-svc/internal/domain/userreassign"
+	webhooksvc "github.com/user/reviewer-svc/internal/domain/webhook"
+	"github.com/user/reviewer-svc/internal/infrastructure/db/memory"
+	postgres "github.com/user/reviewer-svc/internal/infrastructure/db/postgres"
+	"github.com/user/reviewer-svc/internal/infrastructure/db/sqlite"
+)
+
+type teamRepository interface {
+	teamsvc.Repository
+	usersvc.TeamRepository
+	usersvc.BulkTeamRepository
+	prsvc.TeamRepository
+	userreassign.ReassignmentTeamRepository
+}
+
+type userRepository interface {
+	usersvc.UserRepository
+	usersvc.BulkUserRepository
+	usersvc.AbsenceUserRepository
+	usersvc.TagUserRepository
+	prsvc.UserRepository
+	userreassign.ReassignmentUserRepository
+	codeownerssvc.UserRepository
+}
+
+type prRepository interface {
+	prsvc.PullRequestRepository
+	prsvc.ReviewLoadRepository
+	usersvc.ReviewLoadRepository
+	statssvc.PullRequestStatsRepository
+	userreassign.ReassignmentPRRepository
+}
+
+type historyRepository interface {
+	prsvc.AssignmentEventRepository
+	userreassign.ReassignmentEventRepository
+}
+
+type webhookRepository interface {
+	webhooksvc.Outbox
+	webhooksvc.Repository
+	webhooksvc.DeliveryRepository
+}
+
+type pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// Storage is one persistence backend: its transaction manager and the
+// repositories that run inside its transactions.
+type Storage struct {
+	tx         domain.TxManager
+	ping       pinger
+	replica    pinger
+	teams      teamRepository
+	users      userRepository
+	prs        prRepository
+	history    historyRepository
+	webhooks   webhookRepository
+	mappings   integrationsvc.MappingRepository
+	absences   usersvc.AbsenceRepository
+	apiKeys    apikeysvc.Repository
+	idemKeys   idempotencysvc.Repository
+	codeowners codeownerssvc.Repository
+	tags       usersvc.TagRepository
+	rotation   usersvc.RotationRepository
+
+	// pools are the Postgres pools by role, exported as metrics.
+	pools map[string]*pgxpool.Pool
+}
+
+func NewPostgresStorage(pool *pgxpool.Pool) Storage {
+	return Storage{
+		tx:         postgres.NewTxManager(pool),
+		ping:       pool,
+		teams:      postgres.NewTeamRepo(),
+		users:      postgres.NewUserRepo(),
+		prs:        postgres.NewPRRepo(),
+		history:    postgres.NewHistoryRepo(),
+		webhooks:   postgres.NewWebhookRepo(),
+		mappings:   postgres.NewMappingRepo(),
+		absences:   postgres.NewAbsenceRepo(),
+		apiKeys:    postgres.NewAPIKeyRepo(),
+		idemKeys:   postgres.NewIdempotencyRepo(),
+		codeowners: postgres.NewCodeownersRepo(),
+		tags:       postgres.NewTagRepo(),
+		rotation:   postgres.NewRotationRepo(),
+		pools:      map[string]*pgxpool.Pool{"primary": pool},
+	}
+}
+
+// NewReplicatedPostgresStorage is NewPostgresStorage with read-only
+// listings served from replica.
+func NewReplicatedPostgresStorage(primary, replica *pgxpool.Pool) Storage {
+	s := NewPostgresStorage(primary)
+	s.tx = postgres.NewReplicatedTxManager(primary, replica)
+	s.replica = replica
+	s.pools["replica"] = replica
+	return s
+}
+
+func NewSQLiteStorage(db *sql.DB) Storage {
+	tx := sqlite.NewTxManager(db)
+	return Storage{
+		tx:         tx,
+		ping:       tx,
+		teams:      sqlite.NewTeamRepo(),
+		users:      sqlite.NewUserRepo(),
+		prs:        sqlite.NewPRRepo(),
+		history:    sqlite.NewHistoryRepo(),
+		webhooks:   sqlite.NewWebhookRepo(),
+		mappings:   sqlite.NewMappingRepo(),
+		absences:   sqlite.NewAbsenceRepo(),
+		apiKeys:    sqlite.NewAPIKeyRepo(),
+		idemKeys:   sqlite.NewIdempotencyRepo(),
+		codeowners: sqlite.NewCodeownersRepo(),
+		tags:       sqlite.NewTagRepo(),
+		rotation:   sqlite.NewRotationRepo(),
+	}
+}
+
+// NewMemoryStorage keeps all data in process memory; it is lost on exit.
+func NewMemoryStorage() Storage {
+	tx := memory.NewTxManager()
+	return Storage{
+		tx:         tx,
+		ping:       tx,
+		teams:      memory.NewTeamRepo(),
+		users:      memory.NewUserRepo(),
+		prs:        memory.NewPRRepo(),
+		history:    memory.NewHistoryRepo(),
+		webhooks:   memory.NewWebhookRepo(),
+		mappings:   memory.NewMappingRepo(),
+		absences:   memory.NewAbsenceRepo(),
+		apiKeys:    memory.NewAPIKeyRepo(),
+		idemKeys:   memory.NewIdempotencyRepo(),
+		codeowners: memory.NewCodeownersRepo(),
+		tags:       memory.NewTagRepo(),
+		rotation:   memory.NewRotationRepo(),
+	}
+}
@@ -0,0 +1,46 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/user/reviewer-svc/internal/domain"
+)
+
+const absenceBatchSize = 50
+
+const (
+	idempotencyPurgeInterval  = 10 * time.Minute
+	idempotencyPurgeBatchSize = 500
+)
+
+// Worker runs a background job at a fixed interval until its context is
+// cancelled. Job errors are logged and the next tick retries.
+type Worker struct {
+	name     string
+	interval time.Duration
+	job      func(ctx context.Context) error
+	log      *slog.Logger
+}
+
+func NewWorker(name string, interval time.Duration, job func(ctx context.Context) error, log *slog.Logger) *Worker {
+	return &Worker{name: name, interval: interval, job: job, log: log}
+}
+
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	ctx = domain.WithLogger(ctx, w.log.With("job", w.name))
+	for {
+		if err := w.job(ctx); err != nil && ctx.Err() == nil {
+			w.log.Error("background job failed", "job", w.name, "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
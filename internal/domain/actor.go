@@ -0,0 +1,26 @@
+package domain
+
+import "context"
+
+const ActorSystem = "system"
+
+type actorKey struct{}
+
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns who initiated the current operation, falling back
+// to ActorSystem when the caller is unknown.
+func ActorFromContext(ctx context.Context) string {
+	if actor, ok := LookupActor(ctx); ok {
+		return actor
+	}
+	return ActorSystem
+}
+
+// LookupActor returns the actor set by WithActor and whether there is one.
+func LookupActor(ctx context.Context) (string, bool) {
+	actor, ok := ctx.Value(actorKey{}).(string)
+	return actor, ok && actor != ""
+}
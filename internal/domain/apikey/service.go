@@ -0,0 +1,146 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/user/reviewer-svc/internal/domain"
+)
+
+// BootstrapName is the actor recorded for requests made with the bootstrap
+// key from the configuration.
+const BootstrapName = "bootstrap"
+
+type Repository interface {
+	Create(ctx context.Context, tx domain.Tx, k *APIKey) error
+	GetByID(ctx context.Context, tx domain.Tx, id string) (*APIKey, error)
+	GetByHash(ctx context.Context, tx domain.Tx, hash string) (*APIKey, error)
+	List(ctx context.Context, tx domain.Tx) ([]APIKey, error)
+	Revoke(ctx context.Context, tx domain.Tx, id string, at time.Time) error
+}
+
+type Service struct {
+	repo      Repository
+	tx        domain.TxManager
+	clk       domain.Clock
+	idGen     domain.IDGenerator
+	bootstrap string
+}
+
+// NewService builds the key service. bootstrap, when not empty, is a secret
+// that always authenticates with the team:admin scope, so that the first
+// keys can be created; it is never stored.
+func NewService(repo Repository, tx domain.TxManager, clk domain.Clock, idGen domain.IDGenerator, bootstrap string) *Service {
+	return &Service{repo: repo, tx: tx, clk: clk, idGen: idGen, bootstrap: bootstrap}
+}
+
+// CreateKey stores a new key and returns it together with its secret, which
+// cannot be recovered later.
+func (s Service) CreateKey(ctx context.Context, name string, scopes []Scope) (*APIKey, string, error) {
+	if !ValidName(name) {
+		return nil, "", domain.ErrInvalidKeyName
+	}
+	if len(scopes) == 0 {
+		return nil, "", domain.ErrInvalidScope
+	}
+	uniq := make([]Scope, 0, len(scopes))
+	for _, sc := range scopes {
+		if !ValidScope(sc) {
+			return nil, "", domain.ErrInvalidScope
+		}
+		if !slices.Contains(uniq, sc) {
+			uniq = append(uniq, sc)
+		}
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		return nil, "", err
+	}
+	key := &APIKey{
+		ID:        s.idGen.Generate(),
+		Name:      name,
+		Prefix:    secret[:displayPrefixLen],
+		Hash:      HashSecret(secret),
+		Scopes:    uniq,
+		CreatedAt: s.clk.Now(),
+	}
+
+	err = s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
+		return s.repo.Create(ctx, ttx, key)
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return key, secret, nil
+}
+
+func (s Service) ListKeys(ctx context.Context) ([]APIKey, error) {
+	var res []APIKey
+	err := s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
+		list, err := s.repo.List(ctx, ttx)
+		if err != nil {
+			return err
+		}
+		res = list
+		return nil
+	}, domain.ReadOnly())
+	return res, err
+}
+
+// RevokeKey disables a key for good; revoking it again is a no-op.
+func (s Service) RevokeKey(ctx context.Context, id string) error {
+	return s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
+		key, err := s.repo.GetByID(ctx, ttx, id)
+		if err != nil {
+			return err
+		}
+		if key.Revoked() {
+			return nil
+		}
+		return s.repo.Revoke(ctx, ttx, id, s.clk.Now())
+	})
+}
+
+// Authenticate returns the live key whose secret is secret.
+func (s Service) Authenticate(ctx context.Context, secret string) (*APIKey, error) {
+	if secret == "" {
+		return nil, domain.ErrUnauthenticated
+	}
+	if s.bootstrap != "" && subtle.ConstantTimeCompare([]byte(secret), []byte(s.bootstrap)) == 1 {
+		return &APIKey{Name: BootstrapName, Scopes: []Scope{ScopeTeamAdmin}}, nil
+	}
+
+	var key *APIKey
+	err := s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
+		k, err := s.repo.GetByHash(ctx, ttx, HashSecret(secret))
+		if err != nil {
+			return err
+		}
+		key = k
+		return nil
+	}, domain.ReadOnly())
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrUnauthenticated
+	}
+	if err != nil {
+		return nil, err
+	}
+	if key.Revoked() {
+		return nil, domain.ErrUnauthenticated
+	}
+	return key, nil
+}
+
+func newSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return SecretPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
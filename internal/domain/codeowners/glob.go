@@ -0,0 +1,84 @@
+package codeowners
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// compileGlob turns a gitignore-style pattern into a regexp over slash
+// separated paths relative to the repository root:
+//   - a pattern with a slash at its start or middle is anchored to the root,
+//     one without matches at any depth;
+//   - "*" and "?" do not cross slashes, "**" does, "[...]" is a class;
+//   - a trailing slash (or "/**") matches only what is inside a directory,
+//     and any pattern naming a directory owns everything below it.
+func compileGlob(pattern string) (*regexp.Regexp, error) {
+	p := pattern
+	if strings.HasPrefix(p, "!") {
+		return nil, errors.New("negated patterns are not supported")
+	}
+
+	dirOnly := strings.HasSuffix(p, "/")
+	p = strings.TrimSuffix(p, "/")
+	anchored := strings.Contains(p, "/")
+	if strings.HasSuffix(p, "/**") {
+		p = strings.TrimSuffix(p, "/**")
+		dirOnly = true
+	}
+	p = strings.TrimPrefix(p, "/")
+	if p == "" {
+		return nil, errors.New("empty pattern")
+	}
+
+	var b strings.Builder
+	if anchored {
+		b.WriteString("^")
+	} else {
+		b.WriteString("^(?:.*/)?")
+	}
+
+	for i := 0; i < len(p); {
+		segmentStart := i == 0 || p[i-1] == '/'
+		switch c := p[i]; {
+		case segmentStart && strings.HasPrefix(p[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 3
+		case strings.HasPrefix(p[i:], "**"):
+			b.WriteString(".*")
+			i += 2
+		case c == '*':
+			b.WriteString("[^/]*")
+			i++
+		case c == '?':
+			b.WriteString("[^/]")
+			i++
+		case c == '[':
+			end := strings.IndexByte(p[i+1:], ']')
+			if end < 0 {
+				b.WriteString(regexp.QuoteMeta("["))
+				i++
+				continue
+			}
+			class := p[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 2
+		case c == '\\' && i+1 < len(p):
+			b.WriteString(regexp.QuoteMeta(p[i+1 : i+2]))
+			i += 2
+		default:
+			b.WriteString(regexp.QuoteMeta(p[i : i+1]))
+			i++
+		}
+	}
+
+	if dirOnly {
+		b.WriteString("/.+$")
+	} else {
+		b.WriteString("(?:/.*)?$")
+	}
+	return regexp.Compile(b.String())
+}
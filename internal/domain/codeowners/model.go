@@ -0,0 +1,211 @@
+package codeowners
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/user/reviewer-svc/internal/domain"
+)
+
+// MaxSourceLen bounds the CODEOWNERS documents we accept.
+const MaxSourceLen = 64 << 10
+
+// Ruleset is the CODEOWNERS document a team uploaded.
+type Ruleset struct {
+	TeamID    string
+	Source    string
+	UpdatedAt time.Time
+}
+
+// Rule gives the paths matching Pattern to Owners, which are user IDs.
+type Rule struct {
+	Line    int
+	Pattern string
+	Owners  []string
+	// Section is the [Name] heading the rule is under, if any. Owners of
+	// rules in an optional ^[Name] section are preferred reviewers rather
+	// than mandatory ones.
+	Section  string
+	Optional bool
+
+	re *regexp.Regexp
+}
+
+func (r Rule) Match(path string) bool {
+	return r.re.MatchString(path)
+}
+
+// Document is a parsed CODEOWNERS file.
+type Document struct {
+	Rules []Rule
+}
+
+// Parse reads a CODEOWNERS document: one "pattern owner..." rule per line,
+// with # comments and GitLab-style [Section] headings. A heading may list
+// default owners for the rules below it that name none; ^[Section] makes
+// the section optional. Owners are user IDs, with or without a leading @.
+// Errors wrap domain.ErrInvalidCodeowners and name the line.
+func Parse(src string) (Document, error) {
+	if len(src) > MaxSourceLen {
+		return Document{}, fmt.Errorf("%w: longer than %d bytes", domain.ErrInvalidCodeowners, MaxSourceLen)
+	}
+
+	var doc Document
+	var section string
+	var optional bool
+	var defaults []string
+	for i, line := range strings.Split(src, "\n") {
+		n := i + 1
+		fields := splitFields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		if head := fields[0]; strings.HasPrefix(head, "[") || strings.HasPrefix(head, "^[") {
+			name, rest, err := parseSection(line)
+			if err != nil {
+				return Document{}, fmt.Errorf("%w: line %d: %v", domain.ErrInvalidCodeowners, n, err)
+			}
+			owners, err := parseOwners(splitFields(rest))
+			if err != nil {
+				return Document{}, fmt.Errorf("%w: line %d: %v", domain.ErrInvalidCodeowners, n, err)
+			}
+			section, optional, defaults = name, strings.HasPrefix(head, "^"), owners
+			continue
+		}
+
+		re, err := compileGlob(fields[0])
+		if err != nil {
+			return Document{}, fmt.Errorf("%w: line %d: %q: %v", domain.ErrInvalidCodeowners, n, fields[0], err)
+		}
+		owners, err := parseOwners(fields[1:])
+		if err != nil {
+			return Document{}, fmt.Errorf("%w: line %d: %v", domain.ErrInvalidCodeowners, n, err)
+		}
+		if len(owners) == 0 {
+			owners = defaults
+		}
+		doc.Rules = append(doc.Rules, Rule{
+			Line:     n,
+			Pattern:  fields[0],
+			Owners:   owners,
+			Section:  section,
+			Optional: optional,
+			re:       re,
+		})
+	}
+	return doc, nil
+}
+
+// Owners returns who owns paths. As in GitLab, the last rule matching a
+// path within each section decides its owners, and the owners of all
+// sections add up. Mandatory owners come from required sections,
+// preferred ones only from optional sections.
+func (d Document) Owners(paths []string) (mandatory, preferred []string) {
+	for _, p := range paths {
+		last := make(map[string]Rule)
+		for _, r := range d.Rules {
+			if r.Match(p) {
+				last[r.Section] = r
+			}
+		}
+		for _, r := range last {
+			for _, o := range r.Owners {
+				if r.Optional {
+					preferred = appendNew(preferred, o)
+				} else {
+					mandatory = appendNew(mandatory, o)
+				}
+			}
+		}
+	}
+	preferred = slices.DeleteFunc(preferred, func(o string) bool { return slices.Contains(mandatory, o) })
+	slices.Sort(mandatory)
+	slices.Sort(preferred)
+	return mandatory, preferred
+}
+
+// AllOwners lists every owner the document names.
+func (d Document) AllOwners() []string {
+	var res []string
+	for _, r := range d.Rules {
+		for _, o := range r.Owners {
+			res = appendNew(res, o)
+		}
+	}
+	return res
+}
+
+func appendNew(list []string, s string) []string {
+	if slices.Contains(list, s) {
+		return list
+	}
+	return append(list, s)
+}
+
+// splitFields splits a line on unescaped whitespace and drops a trailing
+// comment.
+func splitFields(line string) []string {
+	var fields []string
+	var cur strings.Builder
+	flush := func() {
+		if cur.Len() > 0 {
+			fields = append(fields, cur.String())
+			cur.Reset()
+		}
+	}
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == '\\' && i+1 < len(line):
+			cur.WriteByte(c)
+			cur.WriteByte(line[i+1])
+			i++
+		case c == '#' && cur.Len() == 0:
+			flush()
+			return fields
+		case c == ' ' || c == '\t' || c == '\r':
+			flush()
+		default:
+			cur.WriteByte(c)
+		}
+	}
+	flush()
+	return fields
+}
+
+// parseSection reads a "[Name]" or "^[Name]" heading, optionally followed
+// by an approval count, which we ignore, and returns what follows it.
+func parseSection(line string) (name, rest string, err error) {
+	s := strings.TrimPrefix(strings.TrimSpace(line), "^")
+	end := strings.IndexByte(s, ']')
+	if !strings.HasPrefix(s, "[") || end < 0 {
+		return "", "", fmt.Errorf("malformed section heading")
+	}
+	name = strings.TrimSpace(s[1:end])
+	if name == "" {
+		return "", "", fmt.Errorf("empty section name")
+	}
+	rest = s[end+1:]
+	if strings.HasPrefix(rest, "[") {
+		if close := strings.IndexByte(rest, ']'); close >= 0 {
+			rest = rest[close+1:]
+		}
+	}
+	return name, rest, nil
+}
+
+func parseOwners(fields []string) ([]string, error) {
+	var owners []string
+	for _, f := range fields {
+		id := strings.TrimPrefix(f, "@")
+		if id == "" || strings.ContainsAny(id, "/@") {
+			return nil, fmt.Errorf("owner %q is not a user ID", f)
+		}
+		owners = appendNew(owners, id)
+	}
+	return owners, nil
+}
@@ -0,0 +1,80 @@
+package codeowners
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/user/reviewer-svc/internal/domain"
+	domainuser "github.com/user/reviewer-svc/internal/domain/user"
+)
+
+type Repository interface {
+	Get(ctx context.Context, tx domain.Tx, teamID string) (*Ruleset, error)
+	Save(ctx context.Context, tx domain.Tx, r *Ruleset) error
+}
+
+type UserRepository interface {
+	ListByIDs(ctx context.Context, tx domain.Tx, ids []string) ([]domainuser.User, error)
+}
+
+type Service struct {
+	repo  Repository
+	users UserRepository
+	tx    domain.TxManager
+	clk   domain.Clock
+}
+
+func NewService(repo Repository, users UserRepository, tx domain.TxManager, clk domain.Clock) *Service {
+	return &Service{repo: repo, users: users, tx: tx, clk: clk}
+}
+
+// SetRules replaces the CODEOWNERS document of team teamID with src, which
+// must parse and name only existing users. An empty src removes every rule.
+func (s Service) SetRules(ctx context.Context, teamID, src string) (*Ruleset, Document, error) {
+	doc, err := Parse(src)
+	if err != nil {
+		return nil, Document{}, err
+	}
+
+	rs := &Ruleset{TeamID: teamID, Source: src, UpdatedAt: s.clk.Now()}
+	err = s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
+		owners := doc.AllOwners()
+		found, err := s.users.ListByIDs(ctx, ttx, owners)
+		if err != nil {
+			return err
+		}
+		for _, id := range owners {
+			if !slices.ContainsFunc(found, func(u domainuser.User) bool { return u.ID == id }) {
+				return fmt.Errorf("%w: unknown owner %q", domain.ErrInvalidCodeowners, id)
+			}
+		}
+		return s.repo.Save(ctx, ttx, rs)
+	})
+	if err != nil {
+		return nil, Document{}, err
+	}
+	return rs, doc, nil
+}
+
+// GetRules returns the CODEOWNERS document of team teamID, or ErrNotFound
+// if it never uploaded one.
+func (s Service) GetRules(ctx context.Context, teamID string) (*Ruleset, Document, error) {
+	var res *Ruleset
+	err := s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
+		rs, err := s.repo.Get(ctx, ttx, teamID)
+		if err != nil {
+			return err
+		}
+		res = rs
+		return nil
+	}, domain.ReadOnly())
+	if err != nil {
+		return nil, Document{}, err
+	}
+	doc, err := Parse(res.Source)
+	if err != nil {
+		return nil, Document{}, err
+	}
+	return res, doc, nil
+}
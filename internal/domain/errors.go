@@ -8,6 +8,7 @@ var (
 	ErrNoCandidate   = errors.New("no candidate")
 	ErrBadReviewer   = errors.New("bad reviewer")
 	ErrAlreadyExists = errors.New("already exists")
+	ErrPRExists      = errors.New("PR already exists")
 
 	ErrInvalidRequest = errors.New("invalid request")
 
@@ -19,5 +20,39 @@ var (
 	ErrEmptyBulkUserIDs  = errors.New("empty bulk user IDs")
 	ErrCrossTeamDeactive = errors.New("user does not belong to team")
 
+	ErrInvalidRequiredReviewers = errors.New("invalid required reviewers")
+
+	ErrInvalidWebhookURL   = errors.New("invalid webhook url")
+	ErrInvalidWebhookEvent = errors.New("invalid webhook event")
+
+	ErrInvalidSaturationPolicy = errors.New("invalid saturation policy")
+	ErrInvalidMaxOpenReviews   = errors.New("invalid max open reviews")
+	ErrInvalidFallbackTeams    = errors.New("invalid fallback teams")
+
+	ErrInvalidCodeowners   = errors.New("invalid CODEOWNERS document")
+	ErrInvalidChangedFiles = errors.New("invalid changed files")
+
+	ErrInvalidTags = errors.New("invalid tags")
+
+	ErrInvalidAbsencePeriod = errors.New("absence must end after it starts")
+
+	ErrInvalidTransition = errors.New("invalid PR status transition")
+	ErrPRNotOpen         = errors.New("PR is not open")
+
+	ErrInvalidVerdict     = errors.New("invalid review verdict")
+	ErrNotEnoughApprovals = errors.New("not enough approvals")
+
+	ErrInvalidSignature    = errors.New("invalid signature")
+	ErrUnknownExternalUser = errors.New("external user is not mapped")
+
+	ErrUnauthenticated = errors.New("missing or invalid credentials")
+	ErrForbidden       = errors.New("not permitted")
+	ErrInvalidKeyName  = errors.New("invalid API key name")
+	ErrInvalidScope    = errors.New("invalid API key scope")
+
+	ErrInvalidIdempotencyKey = errors.New("invalid idempotency key")
+	ErrIdempotencyKeyReused  = errors.New("idempotency key was used for a different request")
+	ErrIdempotencyInProgress = errors.New("request with this idempotency key is in progress")
+
 	ErrConstraintViolation = errors.New("constraint violation")
 )
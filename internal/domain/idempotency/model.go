@@ -0,0 +1,31 @@
+package idempotency
+
+import "time"
+
+// MaxKeyLen bounds the Idempotency-Key values we accept.
+const MaxKeyLen = 255
+
+// Record is the outcome of the first request sent with an idempotency key.
+// Keys are scoped to the caller, so two clients cannot see each other's
+// responses even if they pick the same key.
+type Record struct {
+	Scope string
+	Key   string
+	// RequestHash identifies the request the key was first used for;
+	// retries must send the same one.
+	RequestHash string
+	// Status is 0 while the first request is still being handled.
+	Status      int
+	ContentType string
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+func (r Record) Completed() bool {
+	return r.Status != 0
+}
+
+func ValidKey(key string) bool {
+	return key != "" && len(key) <= MaxKeyLen
+}
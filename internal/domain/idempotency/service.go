@@ -0,0 +1,114 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/user/reviewer-svc/internal/domain"
+)
+
+// pendingTimeout is how long a request may hold its key before retries are
+// allowed to take it over. It outlasts the HTTP request timeout, so it only
+// frees keys of requests that died halfway.
+const pendingTimeout = time.Minute
+
+type Repository interface {
+	Create(ctx context.Context, tx domain.Tx, r *Record) error
+	Get(ctx context.Context, tx domain.Tx, scope, key string, forUpdate bool) (*Record, error)
+	Update(ctx context.Context, tx domain.Tx, r *Record) error
+	Delete(ctx context.Context, tx domain.Tx, scope, key string) error
+	DeleteExpired(ctx context.Context, tx domain.Tx, now time.Time, limit int) (int, error)
+}
+
+type Service struct {
+	repo Repository
+	tx   domain.TxManager
+	clk  domain.Clock
+	ttl  time.Duration
+}
+
+// NewService builds the service; responses are kept for ttl.
+func NewService(repo Repository, tx domain.TxManager, clk domain.Clock, ttl time.Duration) *Service {
+	return &Service{repo: repo, tx: tx, clk: clk, ttl: ttl}
+}
+
+// Begin claims key for a request identified by requestHash. It returns the
+// stored record when the request has already been answered; otherwise it
+// returns nil and the caller must handle the request and then call Finish
+// or Abandon.
+func (s Service) Begin(ctx context.Context, scope, key, requestHash string) (*Record, error) {
+	if !ValidKey(key) {
+		return nil, domain.ErrInvalidIdempotencyKey
+	}
+
+	var replay *Record
+	err := s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
+		now := s.clk.Now()
+		fresh := &Record{
+			Scope:       scope,
+			Key:         key,
+			RequestHash: requestHash,
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(s.ttl),
+		}
+
+		rec, err := s.repo.Get(ctx, ttx, scope, key, true)
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			return s.repo.Create(ctx, ttx, fresh)
+		case err != nil:
+			return err
+		case !now.Before(rec.ExpiresAt), !rec.Completed() && now.Sub(rec.CreatedAt) > pendingTimeout:
+			return s.repo.Update(ctx, ttx, fresh)
+		case rec.RequestHash != requestHash:
+			return domain.ErrIdempotencyKeyReused
+		case !rec.Completed():
+			return domain.ErrIdempotencyInProgress
+		}
+		replay = rec
+		return nil
+	})
+	if errors.Is(err, domain.ErrAlreadyExists) {
+		// A concurrent request created the record first.
+		return nil, domain.ErrIdempotencyInProgress
+	}
+	if err != nil {
+		return nil, err
+	}
+	return replay, nil
+}
+
+// Finish stores the response to the request that claimed key.
+func (s Service) Finish(ctx context.Context, scope, key string, status int, contentType string, body []byte) error {
+	return s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
+		rec, err := s.repo.Get(ctx, ttx, scope, key, true)
+		if err != nil {
+			return err
+		}
+		rec.Status = status
+		rec.ContentType = contentType
+		rec.Body = body
+		return s.repo.Update(ctx, ttx, rec)
+	})
+}
+
+// Abandon releases key without storing a response, so that a retry is
+// handled afresh.
+func (s Service) Abandon(ctx context.Context, scope, key string) error {
+	return s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
+		return s.repo.Delete(ctx, ttx, scope, key)
+	})
+}
+
+// PurgeExpired deletes up to limit records past their TTL and returns how
+// many were removed.
+func (s Service) PurgeExpired(ctx context.Context, limit int) (int, error) {
+	var n int
+	err := s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
+		deleted, err := s.repo.DeleteExpired(ctx, ttx, s.clk.Now(), limit)
+		n = deleted
+		return err
+	})
+	return n, err
+}
@@ -0,0 +1,41 @@
+package integration
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	ProviderGitHub = "github"
+	ProviderGitLab = "gitlab"
+)
+
+type UserMapping struct {
+	Provider      string
+	ExternalLogin string
+	UserID        string
+	CreatedAt     time.Time
+}
+
+// Result describes what an inbound event did to the PR.
+type Result string
+
+const (
+	ResultCreated   Result = "created"
+	ResultMerged    Result = "merged"
+	ResultClosed    Result = "closed"
+	ResultReopened  Result = "reopened"
+	ResultSynced    Result = "reviewers_synced"
+	ResultUnchanged Result = "unchanged"
+	ResultIgnored   Result = "ignored"
+)
+
+// ExternalPRID builds the pull_request_id used for a PR that lives in an
+// external code host.
+func ExternalPRID(provider, repo string, number int64) string {
+	return fmt.Sprintf("%s:%s#%d", provider, repo, number)
+}
+
+func ValidProvider(p string) bool {
+	return p == ProviderGitHub || p == ProviderGitLab
+}
@@ -0,0 +1,167 @@
+package integration
+
+import (
+	"context"
+	"errors"
+
+	"github.com/user/reviewer-svc/internal/domain"
+	domainpr "github.com/user/reviewer-svc/internal/domain/pr"
+)
+
+type MappingRepository interface {
+	Upsert(ctx context.Context, tx domain.Tx, m *UserMapping) error
+	Get(ctx context.Context, tx domain.Tx, provider, login string) (*UserMapping, error)
+	List(ctx context.Context, tx domain.Tx, provider string) ([]UserMapping, error)
+}
+
+type PRService interface {
+	CreatePRByID(ctx context.Context, prID, title, authorID string, changedFiles, requiredTags []string) (*domainpr.PullRequest, error)
+	GetPRByIDFromPrimary(ctx context.Context, id string) (*domainpr.PullRequest, error)
+	MarkMergedByID(ctx context.Context, prID string) (*domainpr.PullRequest, error)
+	ClosePRByID(ctx context.Context, prID string) (*domainpr.PullRequest, error)
+	ReopenPRByID(ctx context.Context, prID string) (*domainpr.PullRequest, error)
+	SyncReviewersByID(ctx context.Context, prID string, userIDs []string) (*domainpr.PullRequest, error)
+}
+
+// Service translates pull request lifecycle events of external code hosts
+// into calls on the PR service.
+type Service struct {
+	mappings MappingRepository
+	prs      PRService
+	tx       domain.TxManager
+	clk      domain.Clock
+}
+
+func NewService(mappings MappingRepository, prs PRService, tx domain.TxManager, clk domain.Clock) *Service {
+	return &Service{mappings: mappings, prs: prs, tx: tx, clk: clk}
+}
+
+func (s Service) SetMapping(ctx context.Context, provider, login, userID string) (*UserMapping, error) {
+	if !ValidProvider(provider) || login == "" || userID == "" {
+		return nil, domain.ErrInvalidRequest
+	}
+
+	m := &UserMapping{
+		Provider:      provider,
+		ExternalLogin: login,
+		UserID:        userID,
+		CreatedAt:     s.clk.Now(),
+	}
+	err := s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
+		return s.mappings.Upsert(ctx, ttx, m)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (s Service) ListMappings(ctx context.Context, provider string) ([]UserMapping, error) {
+	var res []UserMapping
+	err := s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
+		list, err := s.mappings.List(ctx, ttx, provider)
+		if err != nil {
+			return err
+		}
+		res = list
+		return nil
+	}, domain.ReadOnly())
+	return res, err
+}
+
+func (s Service) ResolveUser(ctx context.Context, provider, login string) (string, error) {
+	var userID string
+	err := s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
+		m, err := s.mappings.Get(ctx, ttx, provider, login)
+		if err != nil {
+			return err
+		}
+		userID = m.UserID
+		return nil
+	}, domain.ReadOnly())
+	if errors.Is(err, domain.ErrNotFound) {
+		return "", domain.ErrUnknownExternalUser
+	}
+	return userID, err
+}
+
+// OpenPR registers an opened (or reopened) external PR. A known PR that
+// was closed is reopened; redelivered events for a PR that is already open
+// are reported as unchanged.
+func (s Service) OpenPR(ctx context.Context, provider, prID, title, authorLogin string) (*domainpr.PullRequest, Result, error) {
+	authorID, err := s.ResolveUser(ctx, provider, authorLogin)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// PR events carry no file list or skill tags, so neither CODEOWNERS
+	// rules nor tags apply.
+	pr, err := s.prs.CreatePRByID(ctx, prID, title, authorID, nil, nil)
+	if errors.Is(err, domain.ErrPRExists) {
+		pr, err = s.prs.GetPRByIDFromPrimary(ctx, prID)
+		if err != nil {
+			return nil, "", err
+		}
+		if pr.Status != domainpr.PRStatusClosed {
+			return pr, ResultUnchanged, nil
+		}
+		pr, err = s.prs.ReopenPRByID(ctx, prID)
+		if err != nil {
+			return nil, "", err
+		}
+		return pr, ResultReopened, nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return pr, ResultCreated, nil
+}
+
+func (s Service) MergePR(ctx context.Context, prID string) (*domainpr.PullRequest, Result, error) {
+	pr, err := s.prs.MarkMergedByID(ctx, prID)
+	if err != nil {
+		return nil, "", err
+	}
+	return pr, ResultMerged, nil
+}
+
+// ClosePR records an external PR closed without merging. Redelivered events
+// for a PR that is already closed are reported as unchanged.
+func (s Service) ClosePR(ctx context.Context, prID string) (*domainpr.PullRequest, Result, error) {
+	pr, err := s.prs.ClosePRByID(ctx, prID)
+	if errors.Is(err, domain.ErrInvalidTransition) {
+		closed, getErr := s.prs.GetPRByIDFromPrimary(ctx, prID)
+		if getErr == nil && closed.Status == domainpr.PRStatusClosed {
+			return closed, ResultUnchanged, nil
+		}
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return pr, ResultClosed, nil
+}
+
+// SyncReviewers adopts the reviewer list chosen in the external code host.
+// Logins without a mapping are skipped and returned so the caller can report
+// them.
+func (s Service) SyncReviewers(ctx context.Context, provider, prID string, logins []string) (*domainpr.PullRequest, []string, error) {
+	userIDs := make([]string, 0, len(logins))
+	var unmapped []string
+	for _, login := range logins {
+		userID, err := s.ResolveUser(ctx, provider, login)
+		if errors.Is(err, domain.ErrUnknownExternalUser) {
+			unmapped = append(unmapped, login)
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	pr, err := s.prs.SyncReviewersByID(ctx, prID, userIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pr, unmapped, nil
+}
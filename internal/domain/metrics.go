@@ -0,0 +1,31 @@
+package domain
+
+// ReassignCause tells why reviewers were replaced.
+type ReassignCause string
+
+const (
+	ReassignCauseManual           ReassignCause = "manual"
+	ReassignCauseBulkDeactivation ReassignCause = "bulk_deactivation"
+)
+
+// Metrics counts domain events. Services report only after their
+// transaction committed, so rolled back or retried work is not counted.
+type Metrics interface {
+	PRCreated()
+	ReviewersAssigned(team string, n int)
+	Reassigned(cause ReassignCause, n int)
+	NoCandidate()
+	PRMerged()
+}
+
+// NopMetrics discards everything; it is the default when no metrics backend
+// is wired in.
+type NopMetrics struct{}
+
+func (NopMetrics) PRCreated()                    {}
+func (NopMetrics) ReviewersAssigned(string, int) {}
+func (NopMetrics) Reassigned(ReassignCause, int) {}
+func (NopMetrics) NoCandidate()                  {}
+func (NopMetrics) PRMerged()                     {}
+
+var _ Metrics = NopMetrics{}
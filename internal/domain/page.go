@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 500
+)
+
+// Cursor identifies the last row of a page. List queries are ordered by
+// (created_at, id), so a cursor stays valid while new rows are inserted.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// PageRequest asks for at most Limit rows following Cursor. A zero Limit
+// means no limit and is meant for internal callers that need every row.
+type PageRequest struct {
+	Limit  int
+	Cursor *Cursor
+}
+
+type Page[T any] struct {
+	Items      []T
+	NextCursor *Cursor
+}
+
+// NewPage expects rows fetched with Limit+1: the extra row only signals that
+// another page exists and is dropped from Items.
+func NewPage[T any](rows []T, req PageRequest, key func(T) Cursor) Page[T] {
+	if req.Limit <= 0 || len(rows) <= req.Limit {
+		return Page[T]{Items: rows}
+	}
+	rows = rows[:req.Limit]
+	next := key(rows[len(rows)-1])
+	return Page[T]{Items: rows, NextCursor: &next}
+}
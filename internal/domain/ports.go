@@ -31,7 +31,7 @@ type Row interface {
 }
 
 type TxManager interface {
-	WithTx(ctx context.Context, fn func(ctx context.Context, tx Tx) error) error
+	WithTx(ctx context.Context, fn func(ctx context.Context, tx Tx) error, opts ...TxOption) error
 }
 
 type IDGenerator interface {
@@ -0,0 +1,70 @@
+package pr
+
+import (
+	"context"
+	"errors"
+
+	"github.com/user/reviewer-svc/internal/domain"
+	domainuser "github.com/user/reviewer-svc/internal/domain/user"
+)
+
+// authorizeCreate lets users signed in through SSO open pull requests only
+// in their own name; admins may act for anyone.
+func authorizeCreate(ctx context.Context, authorID string) error {
+	p, ok := domain.PrincipalFromContext(ctx)
+	if !ok || p.Role == domain.RoleAdmin || p.UserID == authorID {
+		return nil
+	}
+	return domain.ErrForbidden
+}
+
+// authorizeReassign lets admins replace any reviewer and team leads only
+// reviewers from their own team.
+func (s PRService) authorizeReassign(ctx context.Context, tx domain.Tx, oldReviewer domainuser.User) error {
+	p, ok := domain.PrincipalFromContext(ctx)
+	if !ok || p.Role == domain.RoleAdmin {
+		return nil
+	}
+	if p.Role != domain.RoleTeamLead {
+		return domain.ErrForbidden
+	}
+	lead, err := s.users.GetByID(ctx, tx, p.UserID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.ErrForbidden
+	}
+	if err != nil {
+		return err
+	}
+	if lead.TeamID != oldReviewer.TeamID {
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
+// authorizeLifecycle lets SSO users mark ready, merge, close and reopen
+// their own PRs; team leads may also do so for PRs of their team and admins
+// for any.
+func (s PRService) authorizeLifecycle(ctx context.Context, tx domain.Tx, pr PullRequest) error {
+	p, ok := domain.PrincipalFromContext(ctx)
+	if !ok || p.Role == domain.RoleAdmin || p.UserID == pr.AuthorID {
+		return nil
+	}
+	if p.Role != domain.RoleTeamLead {
+		return domain.ErrForbidden
+	}
+	author, err := s.users.GetByID(ctx, tx, pr.AuthorID)
+	if err != nil {
+		return err
+	}
+	return s.authorizeReassign(ctx, tx, *author)
+}
+
+// authorizeVerdict lets SSO users submit reviews only as themselves, so
+// nobody can approve in another reviewer's name; admins may act for anyone.
+func authorizeVerdict(ctx context.Context, reviewerID string) error {
+	p, ok := domain.PrincipalFromContext(ctx)
+	if !ok || p.Role == domain.RoleAdmin || p.UserID == reviewerID {
+		return nil
+	}
+	return domain.ErrForbidden
+}
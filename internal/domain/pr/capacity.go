@@ -0,0 +1,163 @@
+package pr
+
+import (
+	"context"
+	"slices"
+
+	"github.com/user/reviewer-svc/internal/domain"
+	domainteam "github.com/user/reviewer-svc/internal/domain/team"
+	domainuser "github.com/user/reviewer-svc/internal/domain/user"
+)
+
+type ReviewLoadRepository interface {
+	CountOpenReviews(ctx context.Context, tx domain.Tx, userIDs []string) (map[string]int, error)
+}
+
+// ReviewerPicker runs the assignment strategy over candidates that are below
+// their max_open_reviews cap. When they do not cover the request, the team's
+// saturation policy decides whether capped candidates are used as well.
+type ReviewerPicker struct {
+	strat AssignmentStrategy
+	loads ReviewLoadRepository
+}
+
+func NewReviewerPicker(strat AssignmentStrategy, loads ReviewLoadRepository) ReviewerPicker {
+	return ReviewerPicker{strat: strat, loads: loads}
+}
+
+// BeginAssignment and FinishAssignment bracket the picks of one assignment
+// for the strategy; see AssignmentStrategy.
+func (p ReviewerPicker) BeginAssignment(ctx context.Context, tx domain.Tx, teamIDs []string) error {
+	return p.strat.BeginAssignment(ctx, tx, teamIDs)
+}
+
+func (p ReviewerPicker) FinishAssignment(ctx context.Context, tx domain.Tx, picked []domainuser.User) error {
+	return p.strat.FinishAssignment(ctx, tx, picked)
+}
+
+// PickInitial chooses up to max reviewers. over holds the IDs of reviewers
+// picked although they are at capacity.
+func (p ReviewerPicker) PickInitial(ctx context.Context, tx domain.Tx, policy domainteam.SaturationPolicy, candidates []domainuser.User, requiredTags []string, max int) ([]domainuser.User, map[string]bool, error) {
+	available, saturated, err := p.splitByCapacity(ctx, tx, candidates)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	picked, err := p.strat.ChooseInitialReviewers(ctx, tx, available, requiredTags, max)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(picked) >= max || len(saturated) == 0 {
+		return picked, nil, nil
+	}
+
+	switch policy {
+	case domainteam.SaturationAssignFewer:
+		return picked, nil, nil
+	case domainteam.SaturationFail:
+		if len(picked) == 0 {
+			return nil, nil, domain.ErrNoCandidate
+		}
+		return picked, nil, nil
+	}
+
+	extra, err := p.strat.ChooseInitialReviewers(ctx, tx, saturated, requiredTags, max-len(picked))
+	if err != nil {
+		return nil, nil, err
+	}
+	over := make(map[string]bool, len(extra))
+	for _, u := range extra {
+		over[u.ID] = true
+	}
+	return append(picked, extra...), over, nil
+}
+
+// PickWithOwners is PickInitial for a PR with code owners. Mandatory owners
+// are all picked, even beyond max or their cap, and flagged when at
+// capacity. Preferred owners with capacity left go through the strategy
+// next, and candidates fill whatever slots remain under policy.
+func (p ReviewerPicker) PickWithOwners(ctx context.Context, tx domain.Tx, policy domainteam.SaturationPolicy, owners Owners, candidates []domainuser.User, requiredTags []string, max int) ([]domainuser.User, map[string]bool, error) {
+	if owners.Empty() {
+		return p.PickInitial(ctx, tx, policy, candidates, requiredTags, max)
+	}
+
+	_, saturated, err := p.splitByCapacity(ctx, tx, owners.Mandatory)
+	if err != nil {
+		return nil, nil, err
+	}
+	over := make(map[string]bool, len(saturated))
+	for _, u := range saturated {
+		over[u.ID] = true
+	}
+	picked := slices.Clone(owners.Mandatory)
+
+	if n := max - len(picked); n > 0 {
+		preferred, _, err := p.PickInitial(ctx, tx, domainteam.SaturationAssignFewer, ExcludeUsers(owners.Preferred, userIDs(picked)...), requiredTags, n)
+		if err != nil {
+			return nil, nil, err
+		}
+		picked = append(picked, preferred...)
+	}
+
+	if n := max - len(picked); n > 0 {
+		if len(picked) > 0 && policy == domainteam.SaturationFail {
+			// The owners already review the PR, so the team only tops up.
+			policy = domainteam.SaturationAssignFewer
+		}
+		rest, restOver, err := p.PickInitial(ctx, tx, policy, ExcludeUsers(candidates, userIDs(picked)...), requiredTags, n)
+		if err != nil {
+			return nil, nil, err
+		}
+		picked = append(picked, rest...)
+		for id := range restOver {
+			over[id] = true
+		}
+	}
+	return picked, over, nil
+}
+
+// PickReplacement chooses a single reviewer to take over from oldReviewer.
+// The flag reports that the replacement is at capacity.
+func (p ReviewerPicker) PickReplacement(ctx context.Context, tx domain.Tx, policy domainteam.SaturationPolicy, oldReviewer domainuser.User, candidates []domainuser.User, requiredTags []string) (domainuser.User, bool, error) {
+	available, saturated, err := p.splitByCapacity(ctx, tx, candidates)
+	if err != nil {
+		return domainuser.User{}, false, err
+	}
+
+	if len(available) > 0 || len(saturated) == 0 {
+		u, err := p.strat.ChooseReassignment(ctx, tx, oldReviewer, available, requiredTags)
+		return u, false, err
+	}
+	if policy != domainteam.SaturationAssignAnyway {
+		return domainuser.User{}, false, domain.ErrNoCandidate
+	}
+	u, err := p.strat.ChooseReassignment(ctx, tx, oldReviewer, saturated, requiredTags)
+	return u, err == nil, err
+}
+
+func (p ReviewerPicker) splitByCapacity(ctx context.Context, tx domain.Tx, candidates []domainuser.User) ([]domainuser.User, []domainuser.User, error) {
+	var capped []string
+	for _, c := range candidates {
+		if c.MaxOpenReviews != nil {
+			capped = append(capped, c.ID)
+		}
+	}
+	if len(capped) == 0 {
+		return candidates, nil, nil
+	}
+
+	load, err := p.loads.CountOpenReviews(ctx, tx, capped)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var available, saturated []domainuser.User
+	for _, c := range candidates {
+		if c.HasCapacity(load[c.ID]) {
+			available = append(available, c)
+		} else {
+			saturated = append(saturated, c)
+		}
+	}
+	return available, saturated, nil
+}
@@ -0,0 +1,175 @@
+package pr
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/user/reviewer-svc/internal/domain"
+	domainteam "github.com/user/reviewer-svc/internal/domain/team"
+	domainuser "github.com/user/reviewer-svc/internal/domain/user"
+)
+
+// pickInitialReviewers chooses up to team.RequiredReviewers reviewers for a
+// PR by authorID that changes paths and requires tags: the code owners of paths and members
+// of the author's team first, as its saturation policy allows, and then
+// members of its fallback teams in order until the slots are filled.
+// fallback maps the reviewers borrowed from a fallback team to its name.
+func (s PRService) pickInitialReviewers(ctx context.Context, ttx domain.Tx, team *domainteam.Team, authorID string, paths, tags []string, now time.Time) (selected []domainuser.User, over map[string]bool, fallback map[string]string, err error) {
+	owners, err := s.codeOwners(ctx, ttx, team.ID, authorID, paths, now)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	cands, err := s.users.ListActiveByTeamExcept(ctx, ttx, team.ID, []string{authorID}, now)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	selected, over, err = s.picker.PickWithOwners(ctx, ttx, team.SaturationPolicy, owners, cands, tags, team.RequiredReviewers)
+	noCandidate := errors.Is(err, domain.ErrNoCandidate) && len(team.FallbackTeams) > 0
+	if err != nil && !noCandidate {
+		return nil, nil, nil, err
+	}
+	if len(selected) >= team.RequiredReviewers || len(team.FallbackTeams) == 0 {
+		return selected, over, nil, nil
+	}
+
+	policy := team.SaturationPolicy
+	if policy == domainteam.SaturationFail {
+		// Borrowing is best effort, like topping up: a failing policy only
+		// means nobody at capacity is borrowed.
+		policy = domainteam.SaturationAssignFewer
+	}
+
+	exclude := []string{authorID}
+	for _, u := range selected {
+		exclude = append(exclude, u.ID)
+	}
+	if over == nil {
+		over = make(map[string]bool)
+	}
+	fallback = make(map[string]string)
+	for _, ref := range team.FallbackTeams {
+		missing := team.RequiredReviewers - len(selected)
+		if missing <= 0 {
+			break
+		}
+		cands, err := s.users.ListActiveByTeamExcept(ctx, ttx, ref.ID, exclude, now)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		extra, extraOver, err := s.picker.PickInitial(ctx, ttx, policy, cands, tags, missing)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		for _, u := range extra {
+			exclude = append(exclude, u.ID)
+			fallback[u.ID] = ref.Name
+			if extraOver[u.ID] {
+				over[u.ID] = true
+			}
+		}
+		selected = append(selected, extra...)
+	}
+
+	if noCandidate && len(selected) == 0 {
+		return nil, nil, nil, domain.ErrNoCandidate
+	}
+	return selected, over, fallback, nil
+}
+
+// CandidateRepository lists the members of a team who may review.
+type CandidateRepository interface {
+	ListActiveByTeamExcept(ctx context.Context, tx domain.Tx, teamID string, exclude []string, at time.Time) ([]domainuser.User, error)
+}
+
+// Replacement is the reviewer picked to take over a slot, along with the
+// pool they came from, which also tops up any other vacant slots.
+type Replacement struct {
+	User domainuser.User
+	Over bool
+	Pool []domainuser.User
+	// FallbackTeam names the pool when it is not the author's team.
+	FallbackTeam string
+}
+
+// Borrowed maps everyone who may be assigned from the pool to the fallback
+// team they would be borrowed from.
+func (r Replacement) Borrowed() map[string]string {
+	if r.FallbackTeam == "" {
+		return nil
+	}
+	res := make(map[string]string, len(r.Pool))
+	for _, u := range r.Pool {
+		res[u.ID] = r.FallbackTeam
+	}
+	return res
+}
+
+// Assigned returns the members of the pool among reviewers: the replacement
+// and whoever topped up vacant slots from the pool.
+func (r Replacement) Assigned(reviewers []PRReviewer) []domainuser.User {
+	var res []domainuser.User
+	for _, u := range r.Pool {
+		if slices.ContainsFunc(reviewers, func(rv PRReviewer) bool { return rv.UserID == u.ID }) {
+			res = append(res, u)
+		}
+	}
+	return res
+}
+
+// replacementPools lists the teams PickReplacementWithFallback tries, in
+// order: the old reviewer's team, the author's team, which differs when the
+// old reviewer was borrowed, then the fallback teams of the author's.
+func replacementPools(reviewerTeam, authorTeam *domainteam.Team) []domainteam.TeamRef {
+	pools := []domainteam.TeamRef{
+		{ID: reviewerTeam.ID, Name: reviewerTeam.Name},
+		{ID: authorTeam.ID, Name: authorTeam.Name},
+	}
+	pools = append(pools, authorTeam.FallbackTeams...)
+	seen := make(map[string]bool, len(pools))
+	return slices.DeleteFunc(pools, func(ref domainteam.TeamRef) bool {
+		dup := seen[ref.ID]
+		seen[ref.ID] = true
+		return dup
+	})
+}
+
+// ReplacementTeamIDs returns the IDs of the teams a replacement for a
+// reviewer of reviewerTeam may come from.
+func ReplacementTeamIDs(reviewerTeam, authorTeam *domainteam.Team) []string {
+	pools := replacementPools(reviewerTeam, authorTeam)
+	ids := make([]string, 0, len(pools))
+	for _, ref := range pools {
+		ids = append(ids, ref.ID)
+	}
+	return ids
+}
+
+// PickReplacementWithFallback chooses who takes over from oldReviewer: a
+// member of their team or, when nobody there can, of the author's team or
+// one of its fallback teams, tried in order. The author's team policy decides whether
+// members at capacity may take over, as it does for the initial reviewers.
+func PickReplacementWithFallback(ctx context.Context, tx domain.Tx, users CandidateRepository, picker ReviewerPicker, oldReviewer domainuser.User, reviewerTeam, authorTeam *domainteam.Team, exclude, tags []string, now time.Time) (Replacement, error) {
+	for _, ref := range replacementPools(reviewerTeam, authorTeam) {
+		cands, err := users.ListActiveByTeamExcept(ctx, tx, ref.ID, exclude, now)
+		if err != nil {
+			return Replacement{}, err
+		}
+		cand, over, err := picker.PickReplacement(ctx, tx, authorTeam.SaturationPolicy, oldReviewer, cands, tags)
+		if errors.Is(err, domain.ErrNoCandidate) {
+			continue
+		}
+		if err != nil {
+			return Replacement{}, err
+		}
+
+		res := Replacement{User: cand, Over: over, Pool: cands}
+		if ref.ID != authorTeam.ID {
+			res.FallbackTeam = ref.Name
+		}
+		return res, nil
+	}
+	return Replacement{}, domain.ErrNoCandidate
+}
@@ -0,0 +1,77 @@
+package pr
+
+import (
+	"time"
+
+	domainuser "github.com/user/reviewer-svc/internal/domain/user"
+)
+
+type AssignmentEventType string
+
+const (
+	EventAssigned   AssignmentEventType = "ASSIGNED"
+	EventReassigned AssignmentEventType = "REASSIGNED"
+	EventUnassigned AssignmentEventType = "UNASSIGNED"
+	EventMerged     AssignmentEventType = "MERGED"
+
+	EventReadyForReview AssignmentEventType = "READY_FOR_REVIEW"
+	EventClosed         AssignmentEventType = "CLOSED"
+	EventReopened       AssignmentEventType = "REOPENED"
+)
+
+const (
+	ReasonInitialAssignment = "initial_assignment"
+	ReasonManualReassign    = "manual_reassign"
+	ReasonUserDeactivated   = domainuser.ReassignReasonDeactivated
+	ReasonUserAbsent        = domainuser.ReassignReasonAbsent
+	ReasonExternalSync      = "external_sync"
+	ReasonMerged            = "merged"
+	ReasonReadyForReview    = "ready_for_review"
+	ReasonClosed            = "closed"
+	ReasonReopened          = "reopened"
+)
+
+type AssignmentEvent struct {
+	ID        int64
+	PRID      string
+	Type      AssignmentEventType
+	Slot      *int
+	OldUserID *string
+	NewUserID *string
+	Actor     string
+	Reason    string
+	CreatedAt time.Time
+}
+
+// DiffReviewers describes the transition from one reviewer set to another as
+// a list of slot-level events.
+func DiffReviewers(prID string, before, after []PRReviewer, actor, reason string, at time.Time) []AssignmentEvent {
+	prev := make(map[int]string, len(before))
+	for _, r := range before {
+		prev[r.Slot] = r.UserID
+	}
+
+	var res []AssignmentEvent
+	for _, r := range after {
+		slot := r.Slot
+		newID := r.UserID
+		oldID, ok := prev[slot]
+		delete(prev, slot)
+		switch {
+		case !ok:
+			res = append(res, AssignmentEvent{PRID: prID, Type: EventAssigned, Slot: &slot, NewUserID: &newID, Actor: actor, Reason: reason, CreatedAt: at})
+		case oldID != newID:
+			res = append(res, AssignmentEvent{PRID: prID, Type: EventReassigned, Slot: &slot, OldUserID: &oldID, NewUserID: &newID, Actor: actor, Reason: reason, CreatedAt: at})
+		}
+	}
+
+	for _, r := range before {
+		oldID, ok := prev[r.Slot]
+		if !ok {
+			continue
+		}
+		slot := r.Slot
+		res = append(res, AssignmentEvent{PRID: prID, Type: EventUnassigned, Slot: &slot, OldUserID: &oldID, Actor: actor, Reason: reason, CreatedAt: at})
+	}
+	return res
+}
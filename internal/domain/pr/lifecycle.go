@@ -0,0 +1,59 @@
+package pr
+
+import (
+	"fmt"
+
+	"github.com/user/reviewer-svc/internal/domain"
+)
+
+// Transition is an action that moves a PR from one status to another.
+type Transition string
+
+const (
+	TransitionReady  Transition = "ready"
+	TransitionMerge  Transition = "merge"
+	TransitionClose  Transition = "close"
+	TransitionReopen Transition = "reopen"
+)
+
+// StateMachine maps every status to the transitions allowed from it and the
+// status each of them leads to.
+type StateMachine map[PRStatus]map[Transition]PRStatus
+
+// Lifecycle is the state machine every PR follows. Drafts get reviewers
+// once they are marked ready, closing releases the reviewers and reopening
+// assigns new ones. Merged PRs stay merged.
+var Lifecycle = StateMachine{
+	PRStatusDraft:  {TransitionReady: PRStatusOpen, TransitionClose: PRStatusClosed},
+	PRStatusOpen:   {TransitionMerge: PRStatusMerged, TransitionClose: PRStatusClosed},
+	PRStatusClosed: {TransitionReopen: PRStatusOpen},
+	PRStatusMerged: {},
+}
+
+// Next returns the status t leads to from from, or ErrInvalidTransition.
+func (m StateMachine) Next(from PRStatus, t Transition) (PRStatus, error) {
+	to, ok := m[from][t]
+	if !ok {
+		return "", fmt.Errorf("%w: cannot %s a %s PR", domain.ErrInvalidTransition, t, from)
+	}
+	return to, nil
+}
+
+// ValidStatus reports whether s is one of the statuses of Lifecycle.
+func ValidStatus(s PRStatus) bool {
+	_, ok := Lifecycle[s]
+	return ok
+}
+
+// requireOpen reports why the reviewers of pr cannot change, if they
+// cannot: only OPEN PRs are under review.
+func (pr PullRequest) requireOpen() error {
+	switch pr.Status {
+	case PRStatusOpen:
+		return nil
+	case PRStatusMerged:
+		return domain.ErrAlreadyMerged
+	default:
+		return fmt.Errorf("%w: PR is %s", domain.ErrPRNotOpen, pr.Status)
+	}
+}
@@ -6,16 +6,45 @@ import (
 
 type PRStatus string
 
+// A PR moves between statuses only as Lifecycle allows.
 const (
+	PRStatusDraft  PRStatus = "DRAFT"
 	PRStatusOpen   PRStatus = "OPEN"
 	PRStatusMerged PRStatus = "MERGED"
+	PRStatusClosed PRStatus = "CLOSED"
 )
 
+// ReviewVerdict is the outcome a reviewer submitted; empty means the review
+// is still pending.
+type ReviewVerdict string
+
+const (
+	VerdictApproved         ReviewVerdict = "APPROVED"
+	VerdictChangesRequested ReviewVerdict = "CHANGES_REQUESTED"
+	VerdictCommented        ReviewVerdict = "COMMENTED"
+)
+
+func ValidVerdict(v ReviewVerdict) bool {
+	switch v {
+	case VerdictApproved, VerdictChangesRequested, VerdictCommented:
+		return true
+	}
+	return false
+}
+
 type PRReviewer struct {
 	PRID       string
 	Slot       int
 	UserID     string
 	AssignedAt time.Time
+	Verdict    ReviewVerdict
+	ReviewedAt *time.Time
+	// OverCapacity marks a reviewer assigned beyond their max_open_reviews
+	// cap because the team policy allowed it.
+	OverCapacity bool
+	// FallbackTeam names the team the reviewer was borrowed from when the
+	// author's team could not fill the slot; empty otherwise.
+	FallbackTeam string
 }
 
 type PullRequest struct {
@@ -27,3 +56,23 @@ type PullRequest struct {
 	MergedAt  *time.Time
 	Reviewers []PRReviewer
 }
+
+func (pr PullRequest) OverCapacity() bool {
+	for _, r := range pr.Reviewers {
+		if r.OverCapacity {
+			return true
+		}
+	}
+	return false
+}
+
+// Approvals counts reviewers whose latest verdict is APPROVED.
+func (pr PullRequest) Approvals() int {
+	n := 0
+	for _, r := range pr.Reviewers {
+		if r.Verdict == VerdictApproved {
+			n++
+		}
+	}
+	return n
+}
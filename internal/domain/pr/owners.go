@@ -0,0 +1,97 @@
+package pr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/user/reviewer-svc/internal/domain"
+	"github.com/user/reviewer-svc/internal/domain/codeowners"
+	domainuser "github.com/user/reviewer-svc/internal/domain/user"
+)
+
+// MaxChangedFiles bounds the paths a PR may list, as code hosts do.
+const MaxChangedFiles = 3000
+
+type CodeownersRepository interface {
+	Get(ctx context.Context, tx domain.Tx, teamID string) (*codeowners.Ruleset, error)
+}
+
+// Owners are the code owners of the paths a PR changes who are available
+// to review it.
+type Owners struct {
+	Mandatory []domainuser.User
+	Preferred []domainuser.User
+}
+
+func (o Owners) Empty() bool {
+	return len(o.Mandatory) == 0 && len(o.Preferred) == 0
+}
+
+// codeOwners looks paths up in the CODEOWNERS rules of team teamID. The
+// author and owners who are inactive or absent at now are left out.
+func (s PRService) codeOwners(ctx context.Context, ttx domain.Tx, teamID, authorID string, paths []string, now time.Time) (Owners, error) {
+	if len(paths) == 0 {
+		return Owners{}, nil
+	}
+	rs, err := s.rules.Get(ctx, ttx, teamID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return Owners{}, nil
+	}
+	if err != nil {
+		return Owners{}, err
+	}
+	doc, err := codeowners.Parse(rs.Source)
+	if err != nil {
+		return Owners{}, err
+	}
+
+	mandatory, preferred := doc.Owners(paths)
+	available, err := s.users.ListActiveByIDs(ctx, ttx, slices.Concat(mandatory, preferred), now)
+	if err != nil {
+		return Owners{}, err
+	}
+
+	var res Owners
+	for _, u := range available {
+		switch {
+		case u.ID == authorID:
+		case slices.Contains(mandatory, u.ID):
+			res.Mandatory = append(res.Mandatory, u)
+		default:
+			res.Preferred = append(res.Preferred, u)
+		}
+	}
+	return res, nil
+}
+
+// normalizeChangedFiles cleans up paths relative to the repository root,
+// drops duplicates and sorts them.
+func normalizeChangedFiles(paths []string) ([]string, error) {
+	if len(paths) > MaxChangedFiles {
+		return nil, fmt.Errorf("%w: more than %d paths", domain.ErrInvalidChangedFiles, MaxChangedFiles)
+	}
+
+	res := make([]string, 0, len(paths))
+	for _, p := range paths {
+		clean := path.Clean(strings.TrimLeft(strings.TrimSpace(p), "/"))
+		if clean == "." || clean == ".." || strings.HasPrefix(clean, "../") {
+			return nil, fmt.Errorf("%w: %q is not a path inside the repository", domain.ErrInvalidChangedFiles, p)
+		}
+		res = append(res, clean)
+	}
+	slices.Sort(res)
+	return slices.Compact(res), nil
+}
+
+func userIDs(users []domainuser.User) []string {
+	ids := make([]string, 0, len(users))
+	for _, u := range users {
+		ids = append(ids, u.ID)
+	}
+	return ids
+}
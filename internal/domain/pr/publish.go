@@ -0,0 +1,56 @@
+package pr
+
+import (
+	"context"
+
+	"github.com/user/reviewer-svc/internal/domain"
+	"github.com/user/reviewer-svc/internal/domain/webhook"
+)
+
+func PRPayload(pr PullRequest) webhook.PRPayload {
+	reviewers := make([]string, 0, len(pr.Reviewers))
+	for _, r := range pr.Reviewers {
+		reviewers = append(reviewers, r.UserID)
+	}
+	return webhook.PRPayload{
+		PullRequestID:     pr.ID,
+		PullRequestName:   pr.Title,
+		AuthorID:          pr.AuthorID,
+		Status:            string(pr.Status),
+		AssignedReviewers: reviewers,
+	}
+}
+
+// PublishAssignmentEvents enqueues reviewer.assigned / reviewer.reassigned
+// webhook events for the given history entries.
+func PublishAssignmentEvents(ctx context.Context, tx domain.Tx, outbox webhook.Outbox, events []AssignmentEvent) error {
+	for _, e := range events {
+		var eventType webhook.EventType
+		switch e.Type {
+		case EventAssigned:
+			eventType = webhook.EventReviewerAssigned
+		case EventReassigned:
+			eventType = webhook.EventReviewerReassigned
+		default:
+			continue
+		}
+
+		payload := webhook.ReviewerPayload{
+			PullRequestID: e.PRID,
+			OldUserID:     e.OldUserID,
+			Actor:         e.Actor,
+			Reason:        e.Reason,
+		}
+		if e.Slot != nil {
+			payload.Slot = *e.Slot
+		}
+		if e.NewUserID != nil {
+			payload.NewUserID = *e.NewUserID
+		}
+
+		if err := outbox.Enqueue(ctx, tx, webhook.Event{Type: eventType, Payload: payload, OccurredAt: e.CreatedAt}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -1,7 +1,13 @@
 package pr
 
 import (
+	"context"
+	"sort"
 	"time"
+
+	"github.com/user/reviewer-svc/internal/domain"
+	domainteam "github.com/user/reviewer-svc/internal/domain/team"
+	domainuser "github.com/user/reviewer-svc/internal/domain/user"
 )
 
 func (pr PullRequest) BuildExcludeList(targetUserID string) []string {
@@ -22,6 +28,10 @@ func (pr PullRequest) ReplaceReviewer(oldReviewerID, newReviewerID string, assig
 		if r.UserID == oldReviewerID {
 			r.UserID = newReviewerID
 			r.AssignedAt = assignedAt
+			r.Verdict = ""
+			r.ReviewedAt = nil
+			r.OverCapacity = false
+			r.FallbackTeam = ""
 			replaced = true
 		}
 		newReviewers[i] = r
@@ -35,3 +45,124 @@ func NormalizeReviewerSlots(reviewers []PRReviewer) {
 		reviewers[i].Slot = i + 1
 	}
 }
+
+// Vacancies reports how many reviewer slots are still free for the
+// required number of reviewers.
+func (pr PullRequest) Vacancies(required int) int {
+	if n := required - len(pr.Reviewers); n > 0 {
+		return n
+	}
+	return 0
+}
+
+func AppendReviewers(reviewers []PRReviewer, prID string, users []domainuser.User, assignedAt time.Time) []PRReviewer {
+	for _, u := range users {
+		reviewers = append(reviewers, PRReviewer{
+			PRID:       prID,
+			Slot:       len(reviewers) + 1,
+			UserID:     u.ID,
+			AssignedAt: assignedAt,
+		})
+	}
+	return reviewers
+}
+
+// MarkOverCapacity flags the reviewers whose IDs are set in over.
+func MarkOverCapacity(reviewers []PRReviewer, over map[string]bool) {
+	for i := range reviewers {
+		if over[reviewers[i].UserID] {
+			reviewers[i].OverCapacity = true
+		}
+	}
+}
+
+// MarkFallback records the team each reviewer in fallback was borrowed from.
+func MarkFallback(reviewers []PRReviewer, fallback map[string]string) {
+	for i := range reviewers {
+		if team, ok := fallback[reviewers[i].UserID]; ok {
+			reviewers[i].FallbackTeam = team
+		}
+	}
+}
+
+func ExcludeUsers(users []domainuser.User, exclude ...string) []domainuser.User {
+	res := make([]domainuser.User, 0, len(users))
+	for _, u := range users {
+		skip := false
+		for _, ex := range exclude {
+			if u.ID == ex {
+				skip = true
+				break
+			}
+		}
+		if !skip {
+			res = append(res, u)
+		}
+	}
+	return res
+}
+
+// FillVacantSlots tops reviewers up to the required count using candidates
+// that are not yet assigned to the PR, matching its requiredTags.
+func FillVacantSlots(ctx context.Context, tx domain.Tx, picker ReviewerPicker, policy domainteam.SaturationPolicy, prID string, reviewers []PRReviewer, required int, candidates []domainuser.User, requiredTags []string, assignedAt time.Time) ([]PRReviewer, error) {
+	assigned := make([]string, 0, len(reviewers))
+	for _, r := range reviewers {
+		assigned = append(assigned, r.UserID)
+	}
+	candidates = ExcludeUsers(candidates, assigned...)
+
+	vacant := PullRequest{Reviewers: reviewers}.Vacancies(required)
+	if vacant == 0 || len(candidates) == 0 {
+		return reviewers, nil
+	}
+
+	if policy == domainteam.SaturationFail {
+		// Topping up is best effort: the PR already has its reviewers, so
+		// a failing policy only means nobody at capacity is added.
+		policy = domainteam.SaturationAssignFewer
+	}
+	extra, over, err := picker.PickInitial(ctx, tx, policy, candidates, requiredTags, vacant)
+	if err != nil {
+		return nil, err
+	}
+	reviewers = AppendReviewers(reviewers, prID, extra, assignedAt)
+	MarkOverCapacity(reviewers, over)
+	return reviewers, nil
+}
+
+// WithReviewers returns the reviewer list for exactly userIDs. Reviewers who
+// stay keep their slot and assignment time; new ones take the free slots.
+func (pr PullRequest) WithReviewers(userIDs []string, assignedAt time.Time) []PRReviewer {
+	keep := make(map[string]PRReviewer, len(pr.Reviewers))
+	for _, r := range pr.Reviewers {
+		keep[r.UserID] = r
+	}
+
+	res := make([]PRReviewer, 0, len(userIDs))
+	used := make(map[int]struct{}, len(userIDs))
+	var added []string
+	for _, id := range userIDs {
+		if r, ok := keep[id]; ok {
+			res = append(res, r)
+			used[r.Slot] = struct{}{}
+			delete(keep, id)
+			continue
+		}
+		added = append(added, id)
+	}
+
+	slot := 1
+	for _, id := range added {
+		for {
+			if _, ok := used[slot]; !ok {
+				break
+			}
+			slot++
+		}
+		used[slot] = struct{}{}
+		res = append(res, PRReviewer{PRID: pr.ID, Slot: slot, UserID: id, AssignedAt: assignedAt})
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i].Slot < res[j].Slot })
+	return res
+}
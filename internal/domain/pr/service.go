@@ -2,10 +2,13 @@ package pr
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/user/reviewer-svc/internal/domain"
+	domainteam "github.com/user/reviewer-svc/internal/domain/team"
 	domainuser "github.com/user/reviewer-svc/internal/domain/user"
+	"github.com/user/reviewer-svc/internal/domain/webhook"
 )
 
 type PullRequestRepository interface {
@@ -13,85 +16,203 @@ type PullRequestRepository interface {
 	GetByID(ctx context.Context, tx domain.Tx, id string, forUpdate bool) (*PullRequest, error)
 	UpdateStatus(ctx context.Context, tx domain.Tx, id string, status PRStatus, mergedAt *time.Time) error
 	ReplaceReviewers(ctx context.Context, tx domain.Tx, prID string, reviewers []PRReviewer) error
-	List(ctx context.Context, tx domain.Tx, status *PRStatus) ([]PullRequest, error)
-	ListAssignedTo(ctx context.Context, tx domain.Tx, userID string, status *PRStatus) ([]PullRequest, error)
+	SetReviewVerdict(ctx context.Context, tx domain.Tx, prID, userID string, verdict ReviewVerdict, reviewedAt time.Time) error
+	List(ctx context.Context, tx domain.Tx, status *PRStatus, page domain.PageRequest) (domain.Page[PullRequest], error)
+	ListAssignedTo(ctx context.Context, tx domain.Tx, userID string, status *PRStatus, page domain.PageRequest) (domain.Page[PullRequest], error)
+	SetChangedFiles(ctx context.Context, tx domain.Tx, prID string, paths []string) error
+	ChangedFiles(ctx context.Context, tx domain.Tx, prID string) ([]string, error)
+	SetRequiredTags(ctx context.Context, tx domain.Tx, prID string, tags []string) error
+	RequiredTags(ctx context.Context, tx domain.Tx, prID string) ([]string, error)
 }
 
 type UserRepository interface {
 	GetByID(ctx context.Context, tx domain.Tx, id string) (*domainuser.User, error)
-	ListActiveByTeamExcept(ctx context.Context, tx domain.Tx, teamID string, exclude []string) ([]domainuser.User, error)
+	ListActiveByTeamExcept(ctx context.Context, tx domain.Tx, teamID string, exclude []string, at time.Time) ([]domainuser.User, error)
+	ListActiveByIDs(ctx context.Context, tx domain.Tx, ids []string, at time.Time) ([]domainuser.User, error)
+}
+
+type TeamRepository interface {
+	GetByID(ctx context.Context, tx domain.Tx, id string) (*domainteam.Team, error)
+}
+
+type AssignmentEventRepository interface {
+	Append(ctx context.Context, tx domain.Tx, events []AssignmentEvent) error
+	ListByPR(ctx context.Context, tx domain.Tx, prID string) ([]AssignmentEvent, error)
 }
 
 type AssignmentStrategy interface {
-	ChooseInitialReviewers(ctx context.Context, candidates []domainuser.User, max int) ([]domainuser.User, error)
-	ChooseReassignment(ctx context.Context, oldReviewer domainuser.User, candidates []domainuser.User) (domainuser.User, error)
+	ChooseInitialReviewers(ctx context.Context, tx domain.Tx, candidates []domainuser.User, requiredTags []string, max int) ([]domainuser.User, error)
+	ChooseReassignment(ctx context.Context, tx domain.Tx, oldReviewer domainuser.User, candidates []domainuser.User, requiredTags []string) (domainuser.User, error)
+	// BeginAssignment runs before the first pick of an assignment with the
+	// teams its candidates may come from, and FinishAssignment after the
+	// last pick with everyone picked, so that a strategy keeping state
+	// between assignments moves it once per assignment.
+	BeginAssignment(ctx context.Context, tx domain.Tx, teamIDs []string) error
+	FinishAssignment(ctx context.Context, tx domain.Tx, picked []domainuser.User) error
 }
 
 type PRService struct {
-	prs   PullRequestRepository
-	users UserRepository
-	tx    domain.TxManager
-	clk   domain.Clock
-	idGen domain.IDGenerator
-	strat AssignmentStrategy
+	prs    PullRequestRepository
+	users  UserRepository
+	teams  TeamRepository
+	rules  CodeownersRepository
+	events AssignmentEventRepository
+	outbox webhook.Outbox
+	tx     domain.TxManager
+	clk    domain.Clock
+	idGen  domain.IDGenerator
+	picker ReviewerPicker
+
+	// requiredApprovals blocks MergePR until that many reviewers approved;
+	// zero disables the check.
+	requiredApprovals int
+
+	metrics domain.Metrics
 }
 
-func NewPRService(prs PullRequestRepository, users UserRepository, tx domain.TxManager, clk domain.Clock, idGen domain.IDGenerator, strat AssignmentStrategy) *PRService {
-	return &PRService{prs: prs, users: users, tx: tx, clk: clk, idGen: idGen, strat: strat}
+// NewPRService builds the service; a nil metrics discards the counters.
+func NewPRService(prs PullRequestRepository, users UserRepository, teams TeamRepository, rules CodeownersRepository, events AssignmentEventRepository, outbox webhook.Outbox, tx domain.TxManager, clk domain.Clock, idGen domain.IDGenerator, strat AssignmentStrategy, loads ReviewLoadRepository, requiredApprovals int, metrics domain.Metrics) *PRService {
+	if metrics == nil {
+		metrics = domain.NopMetrics{}
+	}
+	return &PRService{prs: prs, users: users, teams: teams, rules: rules, events: events, outbox: outbox, tx: tx, clk: clk, idGen: idGen, picker: NewReviewerPicker(strat, loads), requiredApprovals: requiredApprovals, metrics: metrics}
 }
 
 func (s PRService) CreatePR(ctx context.Context, title string, authorID string) (*PullRequest, error) {
+	return s.create(ctx, s.idGen.Generate(), title, authorID, PRStatusOpen, nil, nil)
+}
+
+// create opens a PR with reviewers picked from the author's team and the
+// code owners of changedFiles, matching requiredTags where possible, or, for
+// a draft, without any.
+func (s PRService) create(ctx context.Context, prID, title, authorID string, status PRStatus, changedFiles, requiredTags []string) (*PullRequest, error) {
 	if title == "" {
 		return nil, domain.ErrInvalidPRTitle
 	}
+	paths, err := normalizeChangedFiles(changedFiles)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := normalizeRequiredTags(requiredTags)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeCreate(ctx, authorID); err != nil {
+		return nil, err
+	}
 
 	pr := &PullRequest{
-		ID:        s.idGen.Generate(),
+		ID:        prID,
 		Title:     title,
 		AuthorID:  authorID,
-		Status:    PRStatusOpen,
+		Status:    status,
 		CreatedAt: s.clk.Now(),
 	}
 
 	var res *PullRequest
-	err := s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
-		author, err := s.users.GetByID(ctx, ttx, authorID)
-		if err != nil {
-			return err
+	var teamName string
+	err = s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
+		if status == PRStatusDraft {
+			if _, err := s.users.GetByID(ctx, ttx, authorID); err != nil {
+				return err
+			}
+		} else {
+			name, err := s.assignInitialReviewers(ctx, ttx, pr, paths, tags, pr.CreatedAt)
+			if err != nil {
+				return err
+			}
+			teamName = name
 		}
 
-		cands, err := s.users.ListActiveByTeamExcept(ctx, ttx, author.TeamID, []string{author.ID})
-		if err != nil {
+		if err := s.prs.Create(ctx, ttx, pr); err != nil {
+			if errors.Is(err, domain.ErrAlreadyExists) {
+				return domain.ErrPRExists
+			}
 			return err
 		}
-
-		selected, err := s.strat.ChooseInitialReviewers(ctx, cands, 2)
-		if err != nil {
+		if len(paths) > 0 {
+			if err := s.prs.SetChangedFiles(ctx, ttx, pr.ID, paths); err != nil {
+				return err
+			}
+		}
+		if len(tags) > 0 {
+			if err := s.prs.SetRequiredTags(ctx, ttx, pr.ID, tags); err != nil {
+				return err
+			}
+		}
+		events := DiffReviewers(pr.ID, nil, pr.Reviewers, domain.ActorFromContext(ctx), ReasonInitialAssignment, pr.CreatedAt)
+		if err := s.events.Append(ctx, ttx, events); err != nil {
 			return err
 		}
-
-		for i, u := range selected {
-			pr.Reviewers = append(pr.Reviewers, PRReviewer{
-				PRID:       pr.ID,
-				Slot:       i + 1,
-				UserID:     u.ID,
-				AssignedAt: s.clk.Now(),
-			})
+		created := webhook.Event{Type: webhook.EventPRCreated, Payload: PRPayload(*pr), OccurredAt: pr.CreatedAt}
+		if err := s.outbox.Enqueue(ctx, ttx, created); err != nil {
+			return err
 		}
-
-		if err := s.prs.Create(ctx, ttx, pr); err != nil {
+		if err := PublishAssignmentEvents(ctx, ttx, s.outbox, events); err != nil {
 			return err
 		}
 		res = pr
 		return nil
 	})
 	if err != nil {
+		s.observeFailure(err)
 		return nil, err
 	}
+	s.metrics.PRCreated()
+	if len(res.Reviewers) > 0 {
+		s.metrics.ReviewersAssigned(teamName, len(res.Reviewers))
+	}
+	domain.LoggerFromContext(ctx).Info("pr created", "pr_id", res.ID, "pr_status", res.Status, "reviewers", len(res.Reviewers))
 	return res, nil
 }
 
+// assignInitialReviewers picks the first reviewers of pr from the code
+// owners of paths, its author's team, or its fallback teams, matching tags
+// where possible, and returns the author's team name.
+func (s PRService) assignInitialReviewers(ctx context.Context, ttx domain.Tx, pr *PullRequest, paths, tags []string, now time.Time) (string, error) {
+	author, err := s.users.GetByID(ctx, ttx, pr.AuthorID)
+	if err != nil {
+		return "", err
+	}
+
+	team, err := s.teams.GetByID(ctx, ttx, author.TeamID)
+	if err != nil {
+		return "", err
+	}
+
+	teamIDs := []string{team.ID}
+	for _, ref := range team.FallbackTeams {
+		teamIDs = append(teamIDs, ref.ID)
+	}
+	if err := s.picker.BeginAssignment(ctx, ttx, teamIDs); err != nil {
+		return "", err
+	}
+	selected, over, fallback, err := s.pickInitialReviewers(ctx, ttx, team, author.ID, paths, tags, now)
+	if err != nil {
+		return "", err
+	}
+	if err := s.picker.FinishAssignment(ctx, ttx, selected); err != nil {
+		return "", err
+	}
+
+	pr.Reviewers = AppendReviewers(nil, pr.ID, selected, now)
+	MarkOverCapacity(pr.Reviewers, over)
+	MarkFallback(pr.Reviewers, fallback)
+	return team.Name, nil
+}
+
 func (s PRService) GetPRByID(ctx context.Context, id string) (*PullRequest, error) {
+	return s.getPR(ctx, id, domain.AllowReplica())
+}
+
+// GetPRByIDFromPrimary reads the PR without allowing a replica, for callers
+// that must see a write made just before, such as the PR that made their
+// own create fail with ErrPRExists.
+func (s PRService) GetPRByIDFromPrimary(ctx context.Context, id string) (*PullRequest, error) {
+	return s.getPR(ctx, id, domain.ReadOnly())
+}
+
+func (s PRService) getPR(ctx context.Context, id string, opts ...domain.TxOption) (*PullRequest, error) {
 	var res *PullRequest
 	err := s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
 		pr, err := s.prs.GetByID(ctx, ttx, id, false)
@@ -100,86 +221,227 @@ func (s PRService) GetPRByID(ctx context.Context, id string) (*PullRequest, erro
 		}
 		res = pr
 		return nil
-	})
+	}, opts...)
 	return res, err
 }
 
-func (s PRService) ListPRs(ctx context.Context, status *PRStatus) ([]PullRequest, error) {
-	var res []PullRequest
+func (s PRService) ListPRs(ctx context.Context, status *PRStatus, page domain.PageRequest) (domain.Page[PullRequest], error) {
+	var res domain.Page[PullRequest]
 	err := s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
-		list, err := s.prs.List(ctx, ttx, status)
+		list, err := s.prs.List(ctx, ttx, status, page)
 		if err != nil {
 			return err
 		}
 		res = list
 		return nil
-	})
+	}, domain.AllowReplica())
 	return res, err
 }
 
 func (s PRService) ReassignReviewer(ctx context.Context, prID, oldReviewerID string) (*PullRequest, error) {
+	pr, _, err := s.ReassignReviewerByID(ctx, prID, oldReviewerID)
+	return pr, err
+}
+
+func (s PRService) MergePR(ctx context.Context, prID string) (*PullRequest, error) {
+	return s.merge(ctx, prID, true)
+}
+
+// MarkMergedByID records a merge that already happened on a code host, so
+// the approval requirement is not enforced.
+func (s PRService) MarkMergedByID(ctx context.Context, prID string) (*PullRequest, error) {
+	return s.merge(ctx, prID, false)
+}
+
+func (s PRService) merge(ctx context.Context, prID string, checkApprovals bool) (*PullRequest, error) {
 	var res *PullRequest
+	var transitioned bool
 	err := s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
+		transitioned = false
 		pr, err := s.prs.GetByID(ctx, ttx, prID, true)
 		if err != nil {
 			return err
 		}
-		if pr.Status != PRStatusOpen {
-			return domain.ErrAlreadyMerged
+		if err := s.authorizeLifecycle(ctx, ttx, *pr); err != nil {
+			return err
 		}
-
-		oldReviewer, err := s.users.GetByID(ctx, ttx, oldReviewerID)
-		if err != nil {
+		if pr.Status == PRStatusMerged {
+			res = pr
+			return nil
+		}
+		if _, err := Lifecycle.Next(pr.Status, TransitionMerge); err != nil {
 			return err
 		}
+		if checkApprovals && pr.Approvals() < s.requiredApprovals {
+			return domain.ErrNotEnoughApprovals
+		}
+		mergedAt := s.clk.Now()
+		if err := s.prs.UpdateStatus(ctx, ttx, pr.ID, PRStatusMerged, &mergedAt); err != nil {
+			return err
+		}
+		event := AssignmentEvent{PRID: pr.ID, Type: EventMerged, Actor: domain.ActorFromContext(ctx), Reason: ReasonMerged, CreatedAt: mergedAt}
+		if err := s.events.Append(ctx, ttx, []AssignmentEvent{event}); err != nil {
+			return err
+		}
+		pr.Status = PRStatusMerged
+		pr.MergedAt = &mergedAt
+		merged := webhook.Event{Type: webhook.EventPRMerged, Payload: PRPayload(*pr), OccurredAt: mergedAt}
+		if err := s.outbox.Enqueue(ctx, ttx, merged); err != nil {
+			return err
+		}
+		res = pr
+		transitioned = true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if transitioned {
+		s.metrics.PRMerged()
+		domain.LoggerFromContext(ctx).Info("pr merged", "pr_id", res.ID)
+	}
+	return res, nil
+}
 
-		exclude := pr.BuildExcludeList(oldReviewerID)
+// transitionEffects is what each lifecycle transition other than merge
+// records in the history and announces to webhooks.
+var transitionEffects = map[Transition]struct {
+	event   AssignmentEventType
+	reason  string
+	webhook webhook.EventType
+}{
+	TransitionReady:  {EventReadyForReview, ReasonReadyForReview, webhook.EventPRReadyForReview},
+	TransitionClose:  {EventClosed, ReasonClosed, webhook.EventPRClosed},
+	TransitionReopen: {EventReopened, ReasonReopened, webhook.EventPRReopened},
+}
 
-		candidates, err := s.users.ListActiveByTeamExcept(ctx, ttx, oldReviewer.TeamID, exclude)
+// MarkReadyByID turns a draft into an OPEN PR and assigns its reviewers.
+func (s PRService) MarkReadyByID(ctx context.Context, prID string) (*PullRequest, error) {
+	return s.transition(ctx, prID, TransitionReady)
+}
+
+// ClosePRByID abandons a PR without merging it. Its reviewers are released,
+// so the PR no longer counts against their open reviews.
+func (s PRService) ClosePRByID(ctx context.Context, prID string) (*PullRequest, error) {
+	return s.transition(ctx, prID, TransitionClose)
+}
+
+// ReopenPRByID brings a closed PR back under review with newly picked
+// reviewers.
+func (s PRService) ReopenPRByID(ctx context.Context, prID string) (*PullRequest, error) {
+	return s.transition(ctx, prID, TransitionReopen)
+}
+
+func (s PRService) transition(ctx context.Context, prID string, t Transition) (*PullRequest, error) {
+	effects := transitionEffects[t]
+
+	var res *PullRequest
+	var teamName string
+	err := s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
+		pr, err := s.prs.GetByID(ctx, ttx, prID, true)
 		if err != nil {
 			return err
 		}
-		cand, err := s.strat.ChooseReassignment(ctx, *oldReviewer, candidates)
+		if err := s.authorizeLifecycle(ctx, ttx, *pr); err != nil {
+			return err
+		}
+		to, err := Lifecycle.Next(pr.Status, t)
 		if err != nil {
 			return err
 		}
 
 		now := s.clk.Now()
-		newReviewers, replaced := pr.ReplaceReviewer(oldReviewerID, cand.ID, now)
-		if !replaced {
-			return domain.ErrBadReviewer
+		actor := domain.ActorFromContext(ctx)
+		before := pr.Reviewers
+		pr.Status = to
+		switch to {
+		case PRStatusOpen:
+			paths, err := s.prs.ChangedFiles(ctx, ttx, pr.ID)
+			if err != nil {
+				return err
+			}
+			tags, err := s.prs.RequiredTags(ctx, ttx, pr.ID)
+			if err != nil {
+				return err
+			}
+			name, err := s.assignInitialReviewers(ctx, ttx, pr, paths, tags, now)
+			if err != nil {
+				return err
+			}
+			teamName = name
+		case PRStatusClosed:
+			pr.Reviewers = nil
 		}
 
-		if err := s.prs.ReplaceReviewers(ctx, ttx, pr.ID, newReviewers); err != nil {
+		if err := s.prs.UpdateStatus(ctx, ttx, pr.ID, to, nil); err != nil {
+			return err
+		}
+		if err := s.prs.ReplaceReviewers(ctx, ttx, pr.ID, pr.Reviewers); err != nil {
+			return err
+		}
+		reviewerEvents := DiffReviewers(pr.ID, before, pr.Reviewers, actor, effects.reason, now)
+		events := append([]AssignmentEvent{{PRID: pr.ID, Type: effects.event, Actor: actor, Reason: effects.reason, CreatedAt: now}}, reviewerEvents...)
+		if err := s.events.Append(ctx, ttx, events); err != nil {
+			return err
+		}
+		changed := webhook.Event{Type: effects.webhook, Payload: PRPayload(*pr), OccurredAt: now}
+		if err := s.outbox.Enqueue(ctx, ttx, changed); err != nil {
+			return err
+		}
+		if err := PublishAssignmentEvents(ctx, ttx, s.outbox, reviewerEvents); err != nil {
 			return err
 		}
-		pr.Reviewers = newReviewers
 		res = pr
 		return nil
 	})
 	if err != nil {
+		s.observeFailure(err)
 		return nil, err
 	}
+	if res.Status == PRStatusOpen {
+		s.metrics.ReviewersAssigned(teamName, len(res.Reviewers))
+	}
+	domain.LoggerFromContext(ctx).Info("pr status changed", "pr_id", res.ID, "transition", t, "pr_status", res.Status)
 	return res, nil
 }
 
-func (s PRService) MergePR(ctx context.Context, prID string) (*PullRequest, error) {
+// SubmitReviewByID records the verdict of an assigned reviewer. A later
+// verdict from the same reviewer replaces the earlier one.
+func (s PRService) SubmitReviewByID(ctx context.Context, prID, reviewerID string, verdict ReviewVerdict) (*PullRequest, error) {
+	if !ValidVerdict(verdict) {
+		return nil, domain.ErrInvalidVerdict
+	}
+	if err := authorizeVerdict(ctx, reviewerID); err != nil {
+		return nil, err
+	}
+
 	var res *PullRequest
 	err := s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
 		pr, err := s.prs.GetByID(ctx, ttx, prID, true)
 		if err != nil {
 			return err
 		}
-		if pr.Status == PRStatusMerged {
-			res = pr
-			return nil
+		if err := pr.requireOpen(); err != nil {
+			return err
 		}
-		mergedAt := s.clk.Now()
-		if err := s.prs.UpdateStatus(ctx, ttx, pr.ID, PRStatusMerged, &mergedAt); err != nil {
+
+		idx := -1
+		for i, r := range pr.Reviewers {
+			if r.UserID == reviewerID {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			return domain.ErrBadReviewer
+		}
+
+		reviewedAt := s.clk.Now()
+		if err := s.prs.SetReviewVerdict(ctx, ttx, pr.ID, reviewerID, verdict, reviewedAt); err != nil {
 			return err
 		}
-		pr.Status = PRStatusMerged
-		pr.MergedAt = &mergedAt
+		pr.Reviewers[idx].Verdict = verdict
+		pr.Reviewers[idx].ReviewedAt = &reviewedAt
 		res = pr
 		return nil
 	})
@@ -189,71 +451,49 @@ func (s PRService) MergePR(ctx context.Context, prID string) (*PullRequest, erro
 	return res, nil
 }
 
-func (s PRService) ListAssignedPRsByID(ctx context.Context, userID string, status *PRStatus) ([]PullRequest, error) {
-	var res []PullRequest
+func (s PRService) HistoryByID(ctx context.Context, prID string) ([]AssignmentEvent, error) {
+	var res []AssignmentEvent
 	err := s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
-		if _, err := s.users.GetByID(ctx, ttx, userID); err != nil {
+		if _, err := s.prs.GetByID(ctx, ttx, prID, false); err != nil {
 			return err
 		}
-		list, err := s.prs.ListAssignedTo(ctx, ttx, userID, status)
+		list, err := s.events.ListByPR(ctx, ttx, prID)
 		if err != nil {
 			return err
 		}
 		res = list
 		return nil
-	})
+	}, domain.ReadOnly())
 	return res, err
 }
 
-func (s PRService) CreatePRByID(ctx context.Context, prID, title, authorID string) (*PullRequest, error) {
-	if title == "" {
-		return nil, domain.ErrInvalidPRTitle
-	}
-
-	pr := &PullRequest{
-		ID:        prID,
-		Title:     title,
-		AuthorID:  authorID,
-		Status:    PRStatusOpen,
-		CreatedAt: s.clk.Now(),
-	}
-
-	var res *PullRequest
+func (s PRService) ListAssignedPRsByID(ctx context.Context, userID string, status *PRStatus, page domain.PageRequest) (domain.Page[PullRequest], error) {
+	var res domain.Page[PullRequest]
 	err := s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
-		author, err := s.users.GetByID(ctx, ttx, authorID)
-		if err != nil {
-			return err
-		}
-
-		cands, err := s.users.ListActiveByTeamExcept(ctx, ttx, author.TeamID, []string{author.ID})
-		if err != nil {
+		if _, err := s.users.GetByID(ctx, ttx, userID); err != nil {
 			return err
 		}
-
-		selected, err := s.strat.ChooseInitialReviewers(ctx, cands, 2)
+		list, err := s.prs.ListAssignedTo(ctx, ttx, userID, status, page)
 		if err != nil {
 			return err
 		}
+		res = list
+		return nil
+	}, domain.AllowReplica())
+	return res, err
+}
 
-		for i, u := range selected {
-			pr.Reviewers = append(pr.Reviewers, PRReviewer{
-				PRID:       pr.ID,
-				Slot:       i + 1,
-				UserID:     u.ID,
-				AssignedAt: s.clk.Now(),
-			})
-		}
+// CreatePRByID opens a PR; changedFiles, if known, let the CODEOWNERS rules
+// of the author's team pick reviewers, and requiredTags name the skills the
+// reviewers should cover.
+func (s PRService) CreatePRByID(ctx context.Context, prID, title, authorID string, changedFiles, requiredTags []string) (*PullRequest, error) {
+	return s.create(ctx, prID, title, authorID, PRStatusOpen, changedFiles, requiredTags)
+}
 
-		if err := s.prs.Create(ctx, ttx, pr); err != nil {
-			return err
-		}
-		res = pr
-		return nil
-	})
-	if err != nil {
-		return nil, err
-	}
-	return res, nil
+// CreateDraftPRByID opens a draft, which gets no reviewers until it is
+// marked ready. changedFiles and requiredTags are kept for then.
+func (s PRService) CreateDraftPRByID(ctx context.Context, prID, title, authorID string, changedFiles, requiredTags []string) (*PullRequest, error) {
+	return s.create(ctx, prID, title, authorID, PRStatusDraft, changedFiles, requiredTags)
 }
 
 func (s PRService) MergePRByID(ctx context.Context, prID string) (*PullRequest, error) {
@@ -268,43 +508,145 @@ func (s PRService) ReassignReviewerByID(ctx context.Context, prID, oldReviewerID
 		if err != nil {
 			return err
 		}
-		if pr.Status != PRStatusOpen {
-			return domain.ErrAlreadyMerged
+		if err := pr.requireOpen(); err != nil {
+			return err
+		}
+		tags, err := s.prs.RequiredTags(ctx, ttx, pr.ID)
+		if err != nil {
+			return err
 		}
 
 		oldReviewer, err := s.users.GetByID(ctx, ttx, oldReviewerID)
 		if err != nil {
 			return err
 		}
+		if err := s.authorizeReassign(ctx, ttx, *oldReviewer); err != nil {
+			return err
+		}
 
-		exclude := pr.BuildExcludeList(oldReviewerID)
-
-		candidates, err := s.users.ListActiveByTeamExcept(ctx, ttx, oldReviewer.TeamID, exclude)
+		reviewerTeam, err := s.teams.GetByID(ctx, ttx, oldReviewer.TeamID)
 		if err != nil {
 			return err
 		}
-		cand, err := s.strat.ChooseReassignment(ctx, *oldReviewer, candidates)
+		authorTeam, err := s.authorTeam(ctx, ttx, pr.AuthorID)
 		if err != nil {
 			return err
 		}
 
 		now := s.clk.Now()
+		if err := s.picker.BeginAssignment(ctx, ttx, ReplacementTeamIDs(reviewerTeam, authorTeam)); err != nil {
+			return err
+		}
+		rep, err := PickReplacementWithFallback(ctx, ttx, s.users, s.picker, *oldReviewer, reviewerTeam, authorTeam, pr.BuildExcludeList(oldReviewerID), tags, now)
+		if err != nil {
+			return err
+		}
+		cand := rep.User
+
 		newReviewers, replaced := pr.ReplaceReviewer(oldReviewerID, cand.ID, now)
 		if !replaced {
 			return domain.ErrBadReviewer
 		}
+		MarkOverCapacity(newReviewers, map[string]bool{cand.ID: rep.Over})
+
+		newReviewers, err = FillVacantSlots(ctx, ttx, s.picker, authorTeam.SaturationPolicy, pr.ID, newReviewers, authorTeam.RequiredReviewers, rep.Pool, tags, now)
+		if err != nil {
+			return err
+		}
+		if err := s.picker.FinishAssignment(ctx, ttx, rep.Assigned(newReviewers)); err != nil {
+			return err
+		}
+		MarkFallback(newReviewers, rep.Borrowed())
 
 		if err := s.prs.ReplaceReviewers(ctx, ttx, pr.ID, newReviewers); err != nil {
 			return err
 		}
+		events := DiffReviewers(pr.ID, pr.Reviewers, newReviewers, domain.ActorFromContext(ctx), ReasonManualReassign, now)
+		if err := s.events.Append(ctx, ttx, events); err != nil {
+			return err
+		}
+		if err := PublishAssignmentEvents(ctx, ttx, s.outbox, events); err != nil {
+			return err
+		}
 		pr.Reviewers = newReviewers
 		newReviewerID = cand.ID
 		res = pr
 		return nil
 	})
 	if err != nil {
+		s.observeFailure(err)
 		return nil, "", err
 	}
+	s.metrics.Reassigned(domain.ReassignCauseManual, 1)
+	domain.LoggerFromContext(ctx).Info("reviewer reassigned", "pr_id", res.ID, "old_reviewer_id", oldReviewerID, "new_reviewer_id", newReviewerID)
 	return res, newReviewerID, nil
 }
 
+// SyncReviewersByID makes the PR's reviewers exactly userIDs, e.g. to follow
+// a manual change made in an external code host. The author is never kept as
+// a reviewer of their own PR.
+func (s PRService) SyncReviewersByID(ctx context.Context, prID string, userIDs []string) (*PullRequest, error) {
+	var res *PullRequest
+	err := s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
+		pr, err := s.prs.GetByID(ctx, ttx, prID, true)
+		if err != nil {
+			return err
+		}
+		if err := pr.requireOpen(); err != nil {
+			return err
+		}
+
+		wanted := make([]string, 0, len(userIDs))
+		seen := make(map[string]struct{}, len(userIDs))
+		for _, id := range userIDs {
+			if _, ok := seen[id]; ok || id == pr.AuthorID {
+				continue
+			}
+			seen[id] = struct{}{}
+			if _, err := s.users.GetByID(ctx, ttx, id); err != nil {
+				return err
+			}
+			wanted = append(wanted, id)
+		}
+
+		now := s.clk.Now()
+		newReviewers := pr.WithReviewers(wanted, now)
+		events := DiffReviewers(pr.ID, pr.Reviewers, newReviewers, domain.ActorFromContext(ctx), ReasonExternalSync, now)
+		if len(events) == 0 {
+			res = pr
+			return nil
+		}
+
+		if err := s.prs.ReplaceReviewers(ctx, ttx, pr.ID, newReviewers); err != nil {
+			return err
+		}
+		if err := s.events.Append(ctx, ttx, events); err != nil {
+			return err
+		}
+		if err := PublishAssignmentEvents(ctx, ttx, s.outbox, events); err != nil {
+			return err
+		}
+		pr.Reviewers = newReviewers
+		res = pr
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// observeFailure counts failures worth alerting on.
+func (s PRService) observeFailure(err error) {
+	if errors.Is(err, domain.ErrNoCandidate) {
+		s.metrics.NoCandidate()
+	}
+}
+
+func (s PRService) authorTeam(ctx context.Context, ttx domain.Tx, authorID string) (*domainteam.Team, error) {
+	author, err := s.users.GetByID(ctx, ttx, authorID)
+	if err != nil {
+		return nil, err
+	}
+	return s.teams.GetByID(ctx, ttx, author.TeamID)
+}
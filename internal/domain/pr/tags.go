@@ -0,0 +1,21 @@
+package pr
+
+import (
+	"fmt"
+
+	"github.com/user/reviewer-svc/internal/domain"
+	domainuser "github.com/user/reviewer-svc/internal/domain/user"
+)
+
+// normalizeRequiredTags validates the skills a PR asks its reviewers to
+// cover. Tags nobody has are accepted: they just cannot be matched.
+func normalizeRequiredTags(tags []string) ([]string, error) {
+	norm, err := domainuser.NormalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+	if len(norm) > domainuser.MaxRequiredTags {
+		return nil, fmt.Errorf("%w: a PR may require at most %d tags", domain.ErrInvalidTags, domainuser.MaxRequiredTags)
+	}
+	return norm, nil
+}
@@ -0,0 +1,36 @@
+package domain
+
+import "context"
+
+// Role is what an end user signed in through SSO may do beyond working on
+// their own pull requests.
+type Role string
+
+const (
+	RoleMember   Role = "member"
+	RoleTeamLead Role = "team_lead"
+	RoleAdmin    Role = "admin"
+)
+
+// Principal is an end user authenticated by an SSO token. Service
+// credentials such as API keys act through the actor alone and carry no
+// principal, so the per-user rules of the services do not apply to them.
+type Principal struct {
+	UserID string
+	Role   Role
+}
+
+// ActorUserPrefix starts the actor recorded for a principal, followed by
+// their users.id.
+const ActorUserPrefix = "user:"
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
@@ -29,7 +29,7 @@ func (s StatsService) StatsByUser(ctx context.Context, teamID *string) ([]UserAs
 		}
 		res = stats
 		return nil
-	})
+	}, domain.AllowReplica())
 	return res, err
 }
 
@@ -42,6 +42,6 @@ func (s StatsService) StatsByPR(ctx context.Context, teamID *string) ([]PRAssign
 		}
 		res = stats
 		return nil
-	})
+	}, domain.AllowReplica())
 	return res, err
 }
@@ -4,8 +4,55 @@ import (
 	"time"
 )
 
+const (
+	DefaultRequiredReviewers = 2
+	MaxRequiredReviewers     = 10
+	MaxFallbackTeams         = 5
+)
+
+// SaturationPolicy decides what happens when there are fewer candidates
+// below their max_open_reviews cap than reviewers to assign.
+type SaturationPolicy string
+
+const (
+	// SaturationAssignAnyway tops up with reviewers who are at capacity and
+	// flags them as over capacity.
+	SaturationAssignAnyway SaturationPolicy = "ASSIGN_ANYWAY"
+	// SaturationAssignFewer assigns only reviewers who still have capacity.
+	SaturationAssignFewer SaturationPolicy = "ASSIGN_FEWER"
+	// SaturationFail rejects the assignment with ErrNoCandidate when nobody
+	// has capacity left.
+	SaturationFail SaturationPolicy = "FAIL"
+
+	DefaultSaturationPolicy = SaturationAssignAnyway
+)
+
+func ValidSaturationPolicy(p SaturationPolicy) bool {
+	switch p {
+	case SaturationAssignAnyway, SaturationAssignFewer, SaturationFail:
+		return true
+	}
+	return false
+}
+
 type Team struct {
-	ID        string
-	Name      string
-	CreatedAt time.Time
+	ID                string
+	Name              string
+	RequiredReviewers int
+	SaturationPolicy  SaturationPolicy
+	// FallbackTeams lend reviewers, in this order, when the team cannot
+	// fill the required slots on its own. Only a team's own fallbacks are
+	// used, never their fallbacks in turn.
+	FallbackTeams []TeamRef
+	CreatedAt     time.Time
+}
+
+// TeamRef names another team.
+type TeamRef struct {
+	ID   string
+	Name string
+}
+
+func ValidRequiredReviewers(n int) bool {
+	return n >= 1 && n <= MaxRequiredReviewers
 }
@@ -2,6 +2,7 @@ package team
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/user/reviewer-svc/internal/domain"
 )
@@ -9,7 +10,12 @@ import (
 type Repository interface {
 	Create(ctx context.Context, tx domain.Tx, t *Team) error
 	GetByID(ctx context.Context, tx domain.Tx, id string) (*Team, error)
-	List(ctx context.Context, tx domain.Tx) ([]Team, error)
+	GetByName(ctx context.Context, tx domain.Tx, name string) (*Team, error)
+	List(ctx context.Context, tx domain.Tx, page domain.PageRequest) (domain.Page[Team], error)
+	Update(ctx context.Context, tx domain.Tx, t *Team) error
+	// SetFallbacks replaces the fallback teams of teamID with fallbackIDs,
+	// in order.
+	SetFallbacks(ctx context.Context, tx domain.Tx, teamID string, fallbackIDs []string) error
 }
 
 type TeamService struct {
@@ -23,15 +29,23 @@ func NewTeamService(teams Repository, tx domain.TxManager, clk domain.Clock, idG
 	return &TeamService{teams: teams, tx: tx, clk: clk, idGen: idGen}
 }
 
-func (s TeamService) CreateTeam(ctx context.Context, name string) (*Team, error) {
+func (s TeamService) CreateTeam(ctx context.Context, name string, requiredReviewers int, policy SaturationPolicy) (*Team, error) {
 	if name == "" {
 		return nil, domain.ErrInvalidTeamName
 	}
+	if !ValidRequiredReviewers(requiredReviewers) {
+		return nil, domain.ErrInvalidRequiredReviewers
+	}
+	if !ValidSaturationPolicy(policy) {
+		return nil, domain.ErrInvalidSaturationPolicy
+	}
 
 	team := &Team{
-		ID:        s.idGen.Generate(),
-		Name:      name,
-		CreatedAt: s.clk.Now(),
+		ID:                s.idGen.Generate(),
+		Name:              name,
+		RequiredReviewers: requiredReviewers,
+		SaturationPolicy:  policy,
+		CreatedAt:         s.clk.Now(),
 	}
 
 	err := s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
@@ -43,16 +57,16 @@ func (s TeamService) CreateTeam(ctx context.Context, name string) (*Team, error)
 	return team, nil
 }
 
-func (s TeamService) ListTeams(ctx context.Context) ([]Team, error) {
-	var res []Team
+func (s TeamService) ListTeams(ctx context.Context, page domain.PageRequest) (domain.Page[Team], error) {
+	var res domain.Page[Team]
 	err := s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
-		list, err := s.teams.List(ctx, ttx)
+		list, err := s.teams.List(ctx, ttx, page)
 		if err != nil {
 			return err
 		}
 		res = list
 		return nil
-	})
+	}, domain.AllowReplica())
 	return res, err
 }
 
@@ -65,6 +79,98 @@ func (s TeamService) GetTeam(ctx context.Context, id string) (*Team, error) {
 		}
 		res = team
 		return nil
+	}, domain.ReadOnly())
+	return res, err
+}
+
+func (s TeamService) GetTeamByName(ctx context.Context, name string) (*Team, error) {
+	var res *Team
+	err := s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
+		team, err := s.teams.GetByName(ctx, ttx, name)
+		if err != nil {
+			return err
+		}
+		res = team
+		return nil
+	}, domain.ReadOnly())
+	return res, err
+}
+
+func (s TeamService) SetRequiredReviewers(ctx context.Context, id string, requiredReviewers int) (*Team, error) {
+	if !ValidRequiredReviewers(requiredReviewers) {
+		return nil, domain.ErrInvalidRequiredReviewers
+	}
+
+	var res *Team
+	err := s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
+		team, err := s.teams.GetByID(ctx, ttx, id)
+		if err != nil {
+			return err
+		}
+		team.RequiredReviewers = requiredReviewers
+		if err := s.teams.Update(ctx, ttx, team); err != nil {
+			return err
+		}
+		res = team
+		return nil
+	})
+	return res, err
+}
+
+func (s TeamService) SetSaturationPolicy(ctx context.Context, id string, policy SaturationPolicy) (*Team, error) {
+	if !ValidSaturationPolicy(policy) {
+		return nil, domain.ErrInvalidSaturationPolicy
+	}
+
+	var res *Team
+	err := s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
+		team, err := s.teams.GetByID(ctx, ttx, id)
+		if err != nil {
+			return err
+		}
+		team.SaturationPolicy = policy
+		if err := s.teams.Update(ctx, ttx, team); err != nil {
+			return err
+		}
+		res = team
+		return nil
+	})
+	return res, err
+}
+
+// SetFallbackTeams makes the teams called names, in that order, the
+// fallbacks of team id. An empty list removes them all.
+func (s TeamService) SetFallbackTeams(ctx context.Context, id string, names []string) (*Team, error) {
+	if len(names) > MaxFallbackTeams {
+		return nil, fmt.Errorf("%w: at most %d fallback teams", domain.ErrInvalidFallbackTeams, MaxFallbackTeams)
+	}
+
+	var res *Team
+	err := s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
+		team, err := s.teams.GetByID(ctx, ttx, id)
+		if err != nil {
+			return err
+		}
+
+		ids := make([]string, 0, len(names))
+		seen := make(map[string]bool, len(names))
+		for _, name := range names {
+			if name == team.Name || seen[name] {
+				return fmt.Errorf("%w: %q is the team itself or listed twice", domain.ErrInvalidFallbackTeams, name)
+			}
+			seen[name] = true
+			fallback, err := s.teams.GetByName(ctx, ttx, name)
+			if err != nil {
+				return err
+			}
+			ids = append(ids, fallback.ID)
+		}
+
+		if err := s.teams.SetFallbacks(ctx, ttx, team.ID, ids); err != nil {
+			return err
+		}
+		res, err = s.teams.GetByID(ctx, ttx, team.ID)
+		return err
 	})
 	return res, err
 }
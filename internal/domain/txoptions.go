@@ -0,0 +1,68 @@
+package domain
+
+// IsolationLevel is the isolation level a transaction runs at. The zero
+// value keeps the database default.
+type IsolationLevel string
+
+const (
+	IsolationDefault        IsolationLevel = ""
+	IsolationReadCommitted  IsolationLevel = "read committed"
+	IsolationRepeatableRead IsolationLevel = "repeatable read"
+	IsolationSerializable   IsolationLevel = "serializable"
+)
+
+// DefaultTxMaxRetries is how many times a transaction is rerun after a
+// serialization failure or deadlock unless WithMaxRetries says otherwise.
+const DefaultTxMaxRetries = 3
+
+type TxOptions struct {
+	Isolation IsolationLevel
+	ReadOnly  bool
+	// AllowReplica lets the manager serve the transaction from a read
+	// replica, which may lag behind the primary. It implies ReadOnly.
+	AllowReplica bool
+	// MaxRetries bounds the reruns after a transient conflict, so the
+	// callback runs at most MaxRetries+1 times and must not keep state
+	// between runs.
+	MaxRetries int
+}
+
+type TxOption func(*TxOptions)
+
+func WithIsolation(level IsolationLevel) TxOption {
+	return func(o *TxOptions) { o.Isolation = level }
+}
+
+// ReadOnly marks a transaction that only reads, which lets the database
+// skip write bookkeeping.
+func ReadOnly() TxOption {
+	return func(o *TxOptions) { o.ReadOnly = true }
+}
+
+// AllowReplica marks a read-only transaction that tolerates slightly stale
+// data, such as listings and statistics.
+func AllowReplica() TxOption {
+	return func(o *TxOptions) {
+		o.ReadOnly = true
+		o.AllowReplica = true
+	}
+}
+
+// WithMaxRetries overrides DefaultTxMaxRetries; zero disables retries.
+func WithMaxRetries(n int) TxOption {
+	return func(o *TxOptions) {
+		if n < 0 {
+			n = 0
+		}
+		o.MaxRetries = n
+	}
+}
+
+// BuildTxOptions applies opts on top of the defaults.
+func BuildTxOptions(opts ...TxOption) TxOptions {
+	o := TxOptions{MaxRetries: DefaultTxMaxRetries}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
@@ -0,0 +1,28 @@
+package user
+
+import (
+	"time"
+)
+
+// Absence is a period in which a user must not receive new reviews, e.g. a
+// vacation. The window is half-open: [StartsAt, EndsAt).
+type Absence struct {
+	ID              string
+	UserID          string
+	StartsAt        time.Time
+	EndsAt          time.Time
+	Reason          string
+	ReassignOpenPRs bool
+	ReassignedAt    *time.Time
+	CreatedAt       time.Time
+}
+
+func (a Absence) ActiveAt(t time.Time) bool {
+	return !t.Before(a.StartsAt) && t.Before(a.EndsAt)
+}
+
+// ReassignmentDue reports whether the user's open reviews should be handed
+// over now and have not been yet.
+func (a Absence) ReassignmentDue(now time.Time) bool {
+	return a.ReassignOpenPRs && a.ReassignedAt == nil && a.ActiveAt(now)
+}
@@ -0,0 +1,202 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/user/reviewer-svc/internal/domain"
+)
+
+type AbsenceRepository interface {
+	Create(ctx context.Context, tx domain.Tx, a *Absence) error
+	GetByID(ctx context.Context, tx domain.Tx, id string, forUpdate bool) (*Absence, error)
+	Update(ctx context.Context, tx domain.Tx, a *Absence) error
+	Delete(ctx context.Context, tx domain.Tx, id string) error
+	ListByUser(ctx context.Context, tx domain.Tx, userID string) ([]Absence, error)
+	ListReassignmentDue(ctx context.Context, tx domain.Tx, now time.Time, limit int) ([]Absence, error)
+}
+
+type AbsenceUserRepository interface {
+	GetByID(ctx context.Context, tx domain.Tx, id string) (*User, error)
+}
+
+type AbsenceService struct {
+	absences     AbsenceRepository
+	users        AbsenceUserRepository
+	tx           domain.TxManager
+	clk          domain.Clock
+	idGen        domain.IDGenerator
+	reassignment UserReassignmentService
+}
+
+func NewAbsenceService(absences AbsenceRepository, users AbsenceUserRepository, tx domain.TxManager, clk domain.Clock, idGen domain.IDGenerator, reassignment UserReassignmentService) *AbsenceService {
+	return &AbsenceService{
+		absences:     absences,
+		users:        users,
+		tx:           tx,
+		clk:          clk,
+		idGen:        idGen,
+		reassignment: reassignment,
+	}
+}
+
+func (s AbsenceService) CreateAbsence(ctx context.Context, userID string, startsAt, endsAt time.Time, reason string, reassignOpenPRs bool) (*Absence, error) {
+	if !endsAt.After(startsAt) {
+		return nil, domain.ErrInvalidAbsencePeriod
+	}
+
+	a := &Absence{
+		ID:              s.idGen.Generate(),
+		UserID:          userID,
+		StartsAt:        startsAt,
+		EndsAt:          endsAt,
+		Reason:          reason,
+		ReassignOpenPRs: reassignOpenPRs,
+		CreatedAt:       s.clk.Now(),
+	}
+
+	err := s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
+		if _, err := s.users.GetByID(ctx, ttx, userID); err != nil {
+			return err
+		}
+		return s.absences.Create(ctx, ttx, a)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.tryHandOver(ctx, a), nil
+}
+
+func (s AbsenceService) UpdateAbsence(ctx context.Context, id string, startsAt, endsAt time.Time, reason string, reassignOpenPRs bool) (*Absence, error) {
+	if !endsAt.After(startsAt) {
+		return nil, domain.ErrInvalidAbsencePeriod
+	}
+
+	var res *Absence
+	err := s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
+		a, err := s.absences.GetByID(ctx, ttx, id, true)
+		if err != nil {
+			return err
+		}
+
+		if !a.StartsAt.Equal(startsAt) {
+			// A moved window starts a new handover once it begins.
+			a.ReassignedAt = nil
+		}
+		a.StartsAt = startsAt
+		a.EndsAt = endsAt
+		a.Reason = reason
+		a.ReassignOpenPRs = reassignOpenPRs
+
+		if err := s.absences.Update(ctx, ttx, a); err != nil {
+			return err
+		}
+		res = a
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.tryHandOver(ctx, res), nil
+}
+
+func (s AbsenceService) DeleteAbsence(ctx context.Context, id string) error {
+	return s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
+		return s.absences.Delete(ctx, ttx, id)
+	})
+}
+
+func (s AbsenceService) ListAbsences(ctx context.Context, userID string) ([]Absence, error) {
+	var res []Absence
+	err := s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
+		if _, err := s.users.GetByID(ctx, ttx, userID); err != nil {
+			return err
+		}
+		list, err := s.absences.ListByUser(ctx, ttx, userID)
+		if err != nil {
+			return err
+		}
+		res = list
+		return nil
+	}, domain.ReadOnly())
+	return res, err
+}
+
+// ReassignStartedAbsences hands over the open reviews of users whose absence
+// has begun and asked for it. Each absence is processed in its own
+// transaction so that one team without candidates does not block the rest.
+func (s AbsenceService) ReassignStartedAbsences(ctx context.Context, limit int) (int, error) {
+	var due []Absence
+	err := s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
+		list, err := s.absences.ListReassignmentDue(ctx, ttx, s.clk.Now(), limit)
+		if err != nil {
+			return err
+		}
+		due = list
+		return nil
+	}, domain.ReadOnly())
+	if err != nil {
+		return 0, err
+	}
+
+	var errs []error
+	processed := 0
+	for _, d := range due {
+		if _, err := s.handOver(ctx, d.ID); err != nil {
+			domain.LoggerFromContext(ctx).Warn("absence handover failed", "absence_id", d.ID, "user_id", d.UserID, "err", err)
+			errs = append(errs, err)
+			continue
+		}
+		processed++
+	}
+	return processed, errors.Join(errs...)
+}
+
+// tryHandOver starts the handover of a just recorded absence that is already
+// due. A failure is only logged: the absence stays recorded without
+// ReassignedAt and ReassignStartedAbsences retries it.
+func (s AbsenceService) tryHandOver(ctx context.Context, a *Absence) *Absence {
+	if !a.ReassignmentDue(s.clk.Now()) {
+		return a
+	}
+	handed, err := s.handOver(ctx, a.ID)
+	if err != nil {
+		domain.LoggerFromContext(ctx).Warn("absence handover failed", "absence_id", a.ID, "user_id", a.UserID, "err", err)
+		return a
+	}
+	return handed
+}
+
+// handOver reassigns the open reviews of the absence's user in its own
+// transaction if the handover is due, and stamps ReassignedAt.
+func (s AbsenceService) handOver(ctx context.Context, id string) (*Absence, error) {
+	var res *Absence
+	err := s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
+		a, err := s.absences.GetByID(ctx, ttx, id, true)
+		if err != nil {
+			return err
+		}
+		now := s.clk.Now()
+		if !a.ReassignmentDue(now) {
+			res = a
+			return nil
+		}
+		u, err := s.users.GetByID(ctx, ttx, a.UserID)
+		if err != nil {
+			return err
+		}
+		if u.IsActive {
+			if _, err := s.reassignment.ReassignUserInOpenPRs(ctx, ttx, u.TeamID, u, ReassignReasonAbsent); err != nil {
+				return err
+			}
+		}
+		a.ReassignedAt = &now
+		if err := s.absences.Update(ctx, ttx, a); err != nil {
+			return err
+		}
+		res = a
+		return nil
+	})
+	return res, err
+}
@@ -8,11 +8,26 @@ import (
 
 
 type AssignmentStrategy interface {
-	ChooseInitialReviewers(ctx context.Context, candidates []User, max int) ([]User, error)
-	ChooseReassignment(ctx context.Context, oldReviewer User, candidates []User) (User, error)
+	ChooseInitialReviewers(ctx context.Context, tx domain.Tx, candidates []User, requiredTags []string, max int) ([]User, error)
+	ChooseReassignment(ctx context.Context, tx domain.Tx, oldReviewer User, candidates []User, requiredTags []string) (User, error)
+	// BeginAssignment runs before the first pick of an assignment with the
+	// teams its candidates may come from, and FinishAssignment after the
+	// last pick with everyone picked, so that a strategy keeping state
+	// between assignments moves it once per assignment.
+	BeginAssignment(ctx context.Context, tx domain.Tx, teamIDs []string) error
+	FinishAssignment(ctx context.Context, tx domain.Tx, picked []User) error
 }
 
+// stateless gives strategies that keep no state between assignments their
+// no-op assignment hooks.
+type stateless struct{}
+
+func (stateless) BeginAssignment(context.Context, domain.Tx, []string) error { return nil }
+
+func (stateless) FinishAssignment(context.Context, domain.Tx, []User) error { return nil }
+
 type RandomAssignmentStrategy struct {
+	stateless
 	rand domain.Rand
 }
 
@@ -22,7 +37,7 @@ func NewRandomAssignmentStrategy(r domain.Rand) *RandomAssignmentStrategy {
 
 
 
-func (s *RandomAssignmentStrategy) ChooseInitialReviewers(_ context.Context, candidates []User, max int) ([]User, error) {
+func (s *RandomAssignmentStrategy) ChooseInitialReviewers(_ context.Context, _ domain.Tx, candidates []User, _ []string, max int) ([]User, error) {
 	if max <= 0 || len(candidates) == 0 {
 		return nil, nil
 	}
@@ -49,7 +64,7 @@ func (s *RandomAssignmentStrategy) ChooseInitialReviewers(_ context.Context, can
 }
 
 
-func (s *RandomAssignmentStrategy) ChooseReassignment(_ context.Context, oldReviewer User, candidates []User) (User, error) {
+func (s *RandomAssignmentStrategy) ChooseReassignment(_ context.Context, _ domain.Tx, oldReviewer User, candidates []User, _ []string) (User, error) {
 	if len(candidates) == 0 {
 		return User{}, domain.ErrNoCandidate
 	}
@@ -2,9 +2,12 @@ package user
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/user/reviewer-svc/internal/domain"
 	"github.com/user/reviewer-svc/internal/domain/team"
+	"github.com/user/reviewer-svc/internal/domain/webhook"
 )
 
 
@@ -13,6 +16,9 @@ type UserBulkService struct {
 	teams        BulkTeamRepository
 	tx           domain.TxManager
 	reassignment UserReassignmentService
+	outbox       webhook.Outbox
+	clk          domain.Clock
+	metrics      domain.Metrics
 }
 
 type BulkUserRepository interface {
@@ -24,12 +30,19 @@ type BulkTeamRepository interface {
 	GetByID(ctx context.Context, tx domain.Tx, id string) (*team.Team, error)
 }
 
-func NewUserBulkService(users BulkUserRepository, teams BulkTeamRepository, tx domain.TxManager, reassignment UserReassignmentService) *UserBulkService {
+// NewUserBulkService builds the service; a nil metrics discards the counters.
+func NewUserBulkService(users BulkUserRepository, teams BulkTeamRepository, tx domain.TxManager, reassignment UserReassignmentService, outbox webhook.Outbox, clk domain.Clock, metrics domain.Metrics) *UserBulkService {
+	if metrics == nil {
+		metrics = domain.NopMetrics{}
+	}
 	return &UserBulkService{
 		users:        users,
 		teams:        teams,
 		tx:           tx,
 		reassignment: reassignment,
+		outbox:       outbox,
+		clk:          clk,
+		metrics:      metrics,
 	}
 }
 
@@ -54,6 +67,9 @@ func (s UserBulkService) BulkDeactivate(ctx context.Context, teamID string, user
 	var deactivated, reassigned int
 
 	err := s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
+		// The transaction may be rerun after a conflict.
+		deactivated, reassigned = 0, 0
+
 		if _, err := s.teams.GetByID(ctx, ttx, teamID); err != nil {
 			return err
 		}
@@ -86,16 +102,38 @@ func (s UserBulkService) BulkDeactivate(ctx context.Context, teamID string, user
 			}
 			deactivated++
 
-			reassignedForUser, err := s.reassignment.ReassignUserInOpenPRs(ctx, ttx, teamID, u)
+			reassignedForUser, err := s.reassignment.ReassignUserInOpenPRs(ctx, ttx, teamID, u, ReassignReasonDeactivated)
 			if err != nil {
 				return err
 			}
 			reassigned += reassignedForUser
+
+			if err := publishUserDeactivated(ctx, ttx, s.outbox, u, reassignedForUser, s.clk.Now()); err != nil {
+				return err
+			}
 		}
 		return nil
 	})
 	if err != nil {
+		if errors.Is(err, domain.ErrNoCandidate) {
+			s.metrics.NoCandidate()
+		}
 		return 0, 0, err
 	}
+	s.metrics.Reassigned(domain.ReassignCauseBulkDeactivation, reassigned)
+	domain.LoggerFromContext(ctx).Info("users deactivated", "team_id", teamID, "deactivated", deactivated, "reassigned", reassigned)
 	return deactivated, reassigned, nil
 }
+
+func publishUserDeactivated(ctx context.Context, tx domain.Tx, outbox webhook.Outbox, u *User, reassigned int, at time.Time) error {
+	return outbox.Enqueue(ctx, tx, webhook.Event{
+		Type: webhook.EventUserDeactivated,
+		Payload: webhook.UserDeactivatedPayload{
+			UserID:        u.ID,
+			TeamID:        u.TeamID,
+			ReassignedPRs: reassigned,
+			Actor:         domain.ActorFromContext(ctx),
+		},
+		OccurredAt: at,
+	})
+}
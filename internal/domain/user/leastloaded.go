@@ -0,0 +1,92 @@
+package user
+
+import (
+	"context"
+	"sort"
+
+	"github.com/user/reviewer-svc/internal/domain"
+	"github.com/user/reviewer-svc/internal/domain/stats"
+)
+
+type ReviewLoadRepository interface {
+	StatsByUser(ctx context.Context, tx domain.Tx, teamID *string) ([]stats.UserAssignmentsStats, error)
+}
+
+// LeastLoadedAssignmentStrategy prefers candidates with the fewest OPEN PRs
+// under review; ties are broken randomly.
+type LeastLoadedAssignmentStrategy struct {
+	stateless
+	load ReviewLoadRepository
+	rand domain.Rand
+}
+
+func NewLeastLoadedAssignmentStrategy(load ReviewLoadRepository, r domain.Rand) *LeastLoadedAssignmentStrategy {
+	return &LeastLoadedAssignmentStrategy{load: load, rand: r}
+}
+
+func (s *LeastLoadedAssignmentStrategy) ChooseInitialReviewers(ctx context.Context, tx domain.Tx, candidates []User, _ []string, max int) ([]User, error) {
+	if max <= 0 || len(candidates) == 0 {
+		return nil, nil
+	}
+
+	ranked, err := s.rank(ctx, tx, candidates)
+	if err != nil {
+		return nil, err
+	}
+	if len(ranked) > max {
+		ranked = ranked[:max]
+	}
+	return ranked, nil
+}
+
+func (s *LeastLoadedAssignmentStrategy) ChooseReassignment(ctx context.Context, tx domain.Tx, _ User, candidates []User, _ []string) (User, error) {
+	if len(candidates) == 0 {
+		return User{}, domain.ErrNoCandidate
+	}
+
+	ranked, err := s.rank(ctx, tx, candidates)
+	if err != nil {
+		return User{}, err
+	}
+	return ranked[0], nil
+}
+
+func (s *LeastLoadedAssignmentStrategy) rank(ctx context.Context, tx domain.Tx, candidates []User) ([]User, error) {
+	load, err := s.openLoad(ctx, tx, candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]User, len(candidates))
+	copy(res, candidates)
+	for i := len(res) - 1; i > 0; i-- {
+		j := s.rand.Intn(i + 1)
+		res[i], res[j] = res[j], res[i]
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return load[res[i].ID] < load[res[j].ID]
+	})
+	return res, nil
+}
+
+func (s *LeastLoadedAssignmentStrategy) openLoad(ctx context.Context, tx domain.Tx, candidates []User) (map[string]int, error) {
+	load := make(map[string]int, len(candidates))
+	seenTeams := make(map[string]struct{})
+	for _, c := range candidates {
+		if _, ok := seenTeams[c.TeamID]; ok {
+			continue
+		}
+		seenTeams[c.TeamID] = struct{}{}
+
+		teamID := c.TeamID
+		list, err := s.load.StatsByUser(ctx, tx, &teamID)
+		if err != nil {
+			return nil, err
+		}
+		for _, st := range list {
+			load[st.UserID] = st.OpenAssigned
+		}
+	}
+	return load, nil
+}
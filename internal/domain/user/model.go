@@ -5,9 +5,18 @@ import (
 )
 
 type User struct {
-	ID        string
-	Name      string
-	TeamID    string
-	IsActive  bool
-	CreatedAt time.Time
+	ID       string
+	Name     string
+	TeamID   string
+	IsActive bool
+	// MaxOpenReviews caps how many OPEN PRs the user reviews at once; nil
+	// means unlimited.
+	MaxOpenReviews *int
+	CreatedAt      time.Time
+}
+
+// HasCapacity reports whether the user may take another review while
+// already reviewing openReviews PRs.
+func (u User) HasCapacity(openReviews int) bool {
+	return u.MaxOpenReviews == nil || openReviews < *u.MaxOpenReviews
 }
@@ -8,6 +8,13 @@ import (
 
 
 
+// Reasons a user's open reviews are handed over; they are recorded in the
+// PR assignment history.
+const (
+	ReassignReasonDeactivated = "user_deactivated"
+	ReassignReasonAbsent      = "user_absent"
+)
+
 type UserReassignmentService interface {
-	ReassignUserInOpenPRs(ctx context.Context, tx domain.Tx, teamID string, u *User) (int, error)
+	ReassignUserInOpenPRs(ctx context.Context, tx domain.Tx, teamID string, u *User, reason string) (int, error)
 }
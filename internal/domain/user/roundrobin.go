@@ -0,0 +1,151 @@
+package user
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/user/reviewer-svc/internal/domain"
+)
+
+// RotationCursor marks the member of a team picked last by the round-robin
+// strategy. The zero cursor starts the rotation from the first member.
+type RotationCursor struct {
+	TeamID        string
+	LastCreatedAt time.Time
+	LastUserID    string
+}
+
+type RotationRepository interface {
+	// LockCursor returns the cursor of teamID and holds it until the
+	// transaction ends, so concurrent picks for the team queue up.
+	LockCursor(ctx context.Context, tx domain.Tx, teamID string) (RotationCursor, error)
+	SaveCursor(ctx context.Context, tx domain.Tx, c RotationCursor) error
+}
+
+// RoundRobinAssignmentStrategy walks each team's members in a stable order,
+// by creation time and ID, and continues after the member it picked last.
+// The cursor is a position in that order rather than an index, so members
+// who are not candidates this time, such as the author or inactive users,
+// are skipped without anyone else losing their turn. Picks only read the
+// cursors; they move once per assignment, in FinishAssignment, however many
+// picks capacity and tags split the assignment into.
+type RoundRobinAssignmentStrategy struct {
+	cursors RotationRepository
+}
+
+func NewRoundRobinAssignmentStrategy(cursors RotationRepository) *RoundRobinAssignmentStrategy {
+	return &RoundRobinAssignmentStrategy{cursors: cursors}
+}
+
+// BeginAssignment locks the cursors of every team the assignment may pick
+// from, in a fixed order so that transactions picking from several teams do
+// not deadlock.
+func (s *RoundRobinAssignmentStrategy) BeginAssignment(ctx context.Context, tx domain.Tx, teamIDs []string) error {
+	ids := slices.Clone(teamIDs)
+	slices.Sort(ids)
+	for _, teamID := range slices.Compact(ids) {
+		if _, err := s.cursors.LockCursor(ctx, tx, teamID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FinishAssignment moves the cursor of each team to the member picked from
+// it whose turn came last.
+func (s *RoundRobinAssignmentStrategy) FinishAssignment(ctx context.Context, tx domain.Tx, picked []User) error {
+	for _, teamID := range teamIDsOf(picked) {
+		cur, err := s.cursors.LockCursor(ctx, tx, teamID)
+		if err != nil {
+			return err
+		}
+		members := slices.DeleteFunc(slices.Clone(picked), func(u User) bool { return u.TeamID != teamID })
+		ordered := inTurn(members, cur)
+		u := ordered[len(ordered)-1]
+		if err := s.cursors.SaveCursor(ctx, tx, RotationCursor{TeamID: teamID, LastCreatedAt: u.CreatedAt, LastUserID: u.ID}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *RoundRobinAssignmentStrategy) ChooseInitialReviewers(ctx context.Context, tx domain.Tx, candidates []User, _ []string, max int) ([]User, error) {
+	if max <= 0 || len(candidates) == 0 {
+		return nil, nil
+	}
+
+	ordered, err := s.rotate(ctx, tx, candidates)
+	if err != nil {
+		return nil, err
+	}
+	return ordered[:min(max, len(ordered))], nil
+}
+
+func (s *RoundRobinAssignmentStrategy) ChooseReassignment(ctx context.Context, tx domain.Tx, _ User, candidates []User, _ []string) (User, error) {
+	if len(candidates) == 0 {
+		return User{}, domain.ErrNoCandidate
+	}
+
+	ordered, err := s.rotate(ctx, tx, candidates)
+	if err != nil {
+		return User{}, err
+	}
+	return ordered[0], nil
+}
+
+// rotate orders candidates by how soon their turn comes: members after
+// their team's cursor first, then those before it, interleaving teams.
+func (s *RoundRobinAssignmentStrategy) rotate(ctx context.Context, tx domain.Tx, candidates []User) ([]User, error) {
+	turn := make(map[string]int, len(candidates))
+	for _, teamID := range teamIDsOf(candidates) {
+		cur, err := s.cursors.LockCursor(ctx, tx, teamID)
+		if err != nil {
+			return nil, err
+		}
+
+		members := slices.DeleteFunc(slices.Clone(candidates), func(u User) bool { return u.TeamID != teamID })
+		for i, u := range inTurn(members, cur) {
+			turn[u.ID] = i
+		}
+	}
+
+	res := slices.Clone(candidates)
+	slices.SortStableFunc(res, func(a, b User) int {
+		if d := turn[a.ID] - turn[b.ID]; d != 0 {
+			return d
+		}
+		return compareRotation(a, b)
+	})
+	return res, nil
+}
+
+// inTurn orders members of one team by how soon their turn comes after cur.
+func inTurn(members []User, cur RotationCursor) []User {
+	members = slices.Clone(members)
+	slices.SortFunc(members, compareRotation)
+	last := User{ID: cur.LastUserID, CreatedAt: cur.LastCreatedAt}
+	split := slices.IndexFunc(members, func(u User) bool { return compareRotation(u, last) > 0 })
+	if split < 0 {
+		split = len(members)
+	}
+	return slices.Concat(members[split:], members[:split])
+}
+
+// teamIDsOf returns the sorted IDs of the teams of users.
+func teamIDsOf(users []User) []string {
+	ids := make([]string, 0, 1)
+	for _, u := range users {
+		ids = append(ids, u.TeamID)
+	}
+	slices.Sort(ids)
+	return slices.Compact(ids)
+}
+
+func compareRotation(a, b User) int {
+	if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+		return c
+	}
+	return strings.Compare(a.ID, b.ID)
+}
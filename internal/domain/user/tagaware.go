@@ -0,0 +1,129 @@
+package user
+
+import (
+	"context"
+	"slices"
+
+	"github.com/user/reviewer-svc/internal/domain"
+)
+
+type TagLookup interface {
+	ListByUsers(ctx context.Context, tx domain.Tx, userIDs []string) (map[string][]string, error)
+}
+
+// TagAwareAssignmentStrategy matches candidates against the tags a PR
+// requires. Initial reviewers are picked greedily to
+// cover as many required tags as possible over the slots; the remaining
+// slots go to members holding the most required tags, and to members with
+// none of them last. base breaks every tie and handles PRs that require no
+// tags.
+type TagAwareAssignmentStrategy struct {
+	base AssignmentStrategy
+	tags TagLookup
+}
+
+func NewTagAwareAssignmentStrategy(base AssignmentStrategy, tags TagLookup) *TagAwareAssignmentStrategy {
+	return &TagAwareAssignmentStrategy{base: base, tags: tags}
+}
+
+func (s *TagAwareAssignmentStrategy) ChooseInitialReviewers(ctx context.Context, tx domain.Tx, candidates []User, required []string, max int) ([]User, error) {
+	if len(required) == 0 || max <= 0 || len(candidates) == 0 {
+		return s.base.ChooseInitialReviewers(ctx, tx, candidates, required, max)
+	}
+
+	tags, err := s.tags.ListByUsers(ctx, tx, candidateIDs(candidates))
+	if err != nil {
+		return nil, err
+	}
+
+	uncovered := slices.Clone(required)
+	rest := slices.Clone(candidates)
+	var picked []User
+	for len(picked) < max && len(uncovered) > 0 {
+		best, gain := bestMatches(rest, tags, uncovered)
+		if gain == 0 {
+			break
+		}
+		choice, err := s.base.ChooseInitialReviewers(ctx, tx, best, required, 1)
+		if err != nil {
+			return nil, err
+		}
+		u := choice[0]
+		picked = append(picked, u)
+		rest = slices.DeleteFunc(rest, func(c User) bool { return c.ID == u.ID })
+		uncovered = slices.DeleteFunc(uncovered, func(t string) bool { return slices.Contains(tags[u.ID], t) })
+	}
+
+	for len(picked) < max && len(rest) > 0 {
+		best, _ := bestMatches(rest, tags, required)
+		extra, err := s.base.ChooseInitialReviewers(ctx, tx, best, required, max-len(picked))
+		if err != nil {
+			return nil, err
+		}
+		picked = append(picked, extra...)
+		rest = slices.DeleteFunc(rest, func(c User) bool {
+			return slices.ContainsFunc(best, func(b User) bool { return b.ID == c.ID })
+		})
+	}
+	return picked, nil
+}
+
+func (s *TagAwareAssignmentStrategy) BeginAssignment(ctx context.Context, tx domain.Tx, teamIDs []string) error {
+	return s.base.BeginAssignment(ctx, tx, teamIDs)
+}
+
+func (s *TagAwareAssignmentStrategy) FinishAssignment(ctx context.Context, tx domain.Tx, picked []User) error {
+	return s.base.FinishAssignment(ctx, tx, picked)
+}
+
+// ChooseReassignment prefers candidates holding the most required tags the
+// old reviewer covered, or of all the required tags if they covered none.
+func (s *TagAwareAssignmentStrategy) ChooseReassignment(ctx context.Context, tx domain.Tx, oldReviewer User, candidates []User, required []string) (User, error) {
+	if len(required) == 0 || len(candidates) == 0 {
+		return s.base.ChooseReassignment(ctx, tx, oldReviewer, candidates, required)
+	}
+
+	tags, err := s.tags.ListByUsers(ctx, tx, append(candidateIDs(candidates), oldReviewer.ID))
+	if err != nil {
+		return User{}, err
+	}
+
+	wanted := slices.DeleteFunc(slices.Clone(required), func(t string) bool {
+		return !slices.Contains(tags[oldReviewer.ID], t)
+	})
+	if len(wanted) == 0 {
+		wanted = required
+	}
+	best, _ := bestMatches(candidates, tags, wanted)
+	return s.base.ChooseReassignment(ctx, tx, oldReviewer, best, required)
+}
+
+// bestMatches returns the candidates holding the most of wanted, and how
+// many they hold. With no match at all, every candidate is returned.
+func bestMatches(candidates []User, tags map[string][]string, wanted []string) ([]User, int) {
+	var best []User
+	most := 0
+	for _, c := range candidates {
+		n := 0
+		for _, t := range tags[c.ID] {
+			if slices.Contains(wanted, t) {
+				n++
+			}
+		}
+		switch {
+		case n > most:
+			best, most = []User{c}, n
+		case n == most:
+			best = append(best, c)
+		}
+	}
+	return best, most
+}
+
+func candidateIDs(candidates []User) []string {
+	ids := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		ids = append(ids, c.ID)
+	}
+	return ids
+}
@@ -0,0 +1,36 @@
+package user
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/user/reviewer-svc/internal/domain"
+)
+
+const (
+	// MaxUserTags bounds the skills a single user may list.
+	MaxUserTags = 20
+	// MaxRequiredTags bounds the skills a single PR may ask for.
+	MaxRequiredTags = 10
+)
+
+// tagPattern admits short lowercase names such as "go", "sql", "c++" or
+// "k8s.helm".
+var tagPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9+#._-]{0,31}$`)
+
+// NormalizeTags lowercases and trims tags, drops duplicates and sorts them.
+// Errors wrap domain.ErrInvalidTags.
+func NormalizeTags(tags []string) ([]string, error) {
+	res := make([]string, 0, len(tags))
+	for _, t := range tags {
+		norm := strings.ToLower(strings.TrimSpace(t))
+		if !tagPattern.MatchString(norm) {
+			return nil, fmt.Errorf("%w: %q is not a tag", domain.ErrInvalidTags, t)
+		}
+		res = append(res, norm)
+	}
+	slices.Sort(res)
+	return slices.Compact(res), nil
+}
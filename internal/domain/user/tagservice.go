@@ -0,0 +1,141 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/user/reviewer-svc/internal/domain"
+)
+
+type TagRepository interface {
+	ListByUsers(ctx context.Context, tx domain.Tx, userIDs []string) (map[string][]string, error)
+	Add(ctx context.Context, tx domain.Tx, userID string, tags []string) error
+	Replace(ctx context.Context, tx domain.Tx, userID string, tags []string) error
+	Remove(ctx context.Context, tx domain.Tx, userID, tag string) error
+}
+
+type TagUserRepository interface {
+	GetByID(ctx context.Context, tx domain.Tx, id string) (*User, error)
+}
+
+// TagService manages the skill tags of users, which the tag-aware
+// assignment strategy matches against the tags a PR requires.
+type TagService struct {
+	tags  TagRepository
+	users TagUserRepository
+	tx    domain.TxManager
+}
+
+func NewTagService(tags TagRepository, users TagUserRepository, tx domain.TxManager) *TagService {
+	return &TagService{tags: tags, users: users, tx: tx}
+}
+
+func (s TagService) ListTags(ctx context.Context, userID string) ([]string, error) {
+	var res []string
+	err := s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
+		if _, err := s.users.GetByID(ctx, ttx, userID); err != nil {
+			return err
+		}
+		tags, err := s.list(ctx, ttx, userID)
+		if err != nil {
+			return err
+		}
+		res = tags
+		return nil
+	}, domain.ReadOnly())
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// AddTags gives userID the tags it does not have yet and returns all of
+// its tags.
+func (s TagService) AddTags(ctx context.Context, userID string, tags []string) ([]string, error) {
+	norm, err := NormalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []string
+	err = s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
+		if _, err := s.users.GetByID(ctx, ttx, userID); err != nil {
+			return err
+		}
+		if err := s.tags.Add(ctx, ttx, userID, norm); err != nil {
+			return err
+		}
+		tags, err := s.list(ctx, ttx, userID)
+		if err != nil {
+			return err
+		}
+		if len(tags) > MaxUserTags {
+			return fmt.Errorf("%w: a user may have at most %d tags", domain.ErrInvalidTags, MaxUserTags)
+		}
+		res = tags
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SetTags replaces the tags of userID; an empty list removes them all.
+func (s TagService) SetTags(ctx context.Context, userID string, tags []string) ([]string, error) {
+	norm, err := NormalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+	if len(norm) > MaxUserTags {
+		return nil, fmt.Errorf("%w: a user may have at most %d tags", domain.ErrInvalidTags, MaxUserTags)
+	}
+
+	err = s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
+		if _, err := s.users.GetByID(ctx, ttx, userID); err != nil {
+			return err
+		}
+		return s.tags.Replace(ctx, ttx, userID, norm)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return norm, nil
+}
+
+// RemoveTag takes tag away from userID, or returns ErrNotFound if it does
+// not have it, and returns the tags left.
+func (s TagService) RemoveTag(ctx context.Context, userID, tag string) ([]string, error) {
+	norm, err := NormalizeTags([]string{tag})
+	if err != nil {
+		return nil, err
+	}
+
+	var res []string
+	err = s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
+		if err := s.tags.Remove(ctx, ttx, userID, norm[0]); err != nil {
+			return err
+		}
+		tags, err := s.list(ctx, ttx, userID)
+		if err != nil {
+			return err
+		}
+		res = tags
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s TagService) list(ctx context.Context, ttx domain.Tx, userID string) ([]string, error) {
+	byUser, err := s.tags.ListByUsers(ctx, ttx, []string{userID})
+	if err != nil {
+		return nil, err
+	}
+	if byUser[userID] == nil {
+		return []string{}, nil
+	}
+	return byUser[userID], nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/user/reviewer-svc/internal/domain"
 	"github.com/user/reviewer-svc/internal/domain/team"
+	"github.com/user/reviewer-svc/internal/domain/webhook"
 )
 
 type UserRepository interface {
@@ -12,7 +13,7 @@ type UserRepository interface {
 	Upsert(ctx context.Context, tx domain.Tx, u *User) error
 	GetByID(ctx context.Context, tx domain.Tx, id string) (*User, error)
 	Update(ctx context.Context, tx domain.Tx, u *User) error
-	List(ctx context.Context, tx domain.Tx, teamID *string, isActive *bool) ([]User, error)
+	List(ctx context.Context, tx domain.Tx, teamID *string, isActive *bool, page domain.PageRequest) (domain.Page[User], error)
 }
 
 type TeamRepository interface {
@@ -26,9 +27,10 @@ type UserService struct {
 	clk          domain.Clock
 	idGen        domain.IDGenerator
 	reassignment UserReassignmentService
+	outbox       webhook.Outbox
 }
 
-func NewUserService(users UserRepository, teams TeamRepository, tx domain.TxManager, clk domain.Clock, idGen domain.IDGenerator, reassignment UserReassignmentService) *UserService {
+func NewUserService(users UserRepository, teams TeamRepository, tx domain.TxManager, clk domain.Clock, idGen domain.IDGenerator, reassignment UserReassignmentService, outbox webhook.Outbox) *UserService {
 	return &UserService{
 		users:        users,
 		teams:        teams,
@@ -36,6 +38,7 @@ func NewUserService(users UserRepository, teams TeamRepository, tx domain.TxMana
 		clk:          clk,
 		idGen:        idGen,
 		reassignment: reassignment,
+		outbox:       outbox,
 	}
 }
 
@@ -69,16 +72,16 @@ func (s UserService) CreateUser(ctx context.Context, teamID string, name string,
 	return res, nil
 }
 
-func (s UserService) ListUsers(ctx context.Context, teamID *string, isActive *bool) ([]User, error) {
-	var res []User
+func (s UserService) ListUsers(ctx context.Context, teamID *string, isActive *bool, page domain.PageRequest) (domain.Page[User], error) {
+	var res domain.Page[User]
 	err := s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
-		list, err := s.users.List(ctx, ttx, teamID, isActive)
+		list, err := s.users.List(ctx, ttx, teamID, isActive, page)
 		if err != nil {
 			return err
 		}
 		res = list
 		return nil
-	})
+	}, domain.AllowReplica())
 	return res, err
 }
 
@@ -91,7 +94,7 @@ func (s UserService) GetUser(ctx context.Context, id string) (*User, error) {
 		}
 		res = u
 		return nil
-	})
+	}, domain.ReadOnly())
 	return res, err
 }
 
@@ -123,7 +126,11 @@ func (s UserService) UpdateUser(ctx context.Context, id string, name *string, is
 
 
 		if u.IsActive && !newIsActive {
-			if _, err := s.reassignment.ReassignUserInOpenPRs(ctx, ttx, u.TeamID, u); err != nil {
+			reassigned, err := s.reassignment.ReassignUserInOpenPRs(ctx, ttx, u.TeamID, u, ReassignReasonDeactivated)
+			if err != nil {
+				return err
+			}
+			if err := publishUserDeactivated(ctx, ttx, s.outbox, u, reassigned, s.clk.Now()); err != nil {
 				return err
 			}
 		}
@@ -140,6 +147,29 @@ func (s UserService) UpdateUser(ctx context.Context, id string, name *string, is
 	return res, err
 }
 
+// SetMaxOpenReviews caps how many open PRs the user reviews at once; nil
+// removes the limit.
+func (s UserService) SetMaxOpenReviews(ctx context.Context, id string, max *int) (*User, error) {
+	if max != nil && *max < 0 {
+		return nil, domain.ErrInvalidMaxOpenReviews
+	}
+
+	var res *User
+	err := s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
+		u, err := s.users.GetByID(ctx, ttx, id)
+		if err != nil {
+			return err
+		}
+		u.MaxOpenReviews = max
+		if err := s.users.Update(ctx, ttx, u); err != nil {
+			return err
+		}
+		res = u
+		return nil
+	})
+	return res, err
+}
+
 func (s UserService) UpsertUserByID(ctx context.Context, userID string, teamID string, name string, isActive bool) (*User, error) {
 	if name == "" {
 		return nil, domain.ErrInvalidUserName
@@ -2,83 +2,133 @@ package userreassign
 
 import (
 	"context"
+	"time"
 
 	"github.com/user/reviewer-svc/internal/domain"
 	prdomain "github.com/user/reviewer-svc/internal/domain/pr"
+	domainteam "github.com/user/reviewer-svc/internal/domain/team"
 	domainuser "github.com/user/reviewer-svc/internal/domain/user"
+	"github.com/user/reviewer-svc/internal/domain/webhook"
 )
 
 type ReassignmentPRRepository interface {
-	ListAssignedTo(ctx context.Context, tx domain.Tx, userID string, status *prdomain.PRStatus) ([]prdomain.PullRequest, error)
+	ListAssignedTo(ctx context.Context, tx domain.Tx, userID string, status *prdomain.PRStatus, page domain.PageRequest) (domain.Page[prdomain.PullRequest], error)
 	ReplaceReviewers(ctx context.Context, tx domain.Tx, prID string, reviewers []prdomain.PRReviewer) error
+	RequiredTags(ctx context.Context, tx domain.Tx, prID string) ([]string, error)
 }
 
 type ReassignmentUserRepository interface {
-	ListActiveByTeamExcept(ctx context.Context, tx domain.Tx, teamID string, exclude []string) ([]domainuser.User, error)
+	GetByID(ctx context.Context, tx domain.Tx, id string) (*domainuser.User, error)
+	ListActiveByTeamExcept(ctx context.Context, tx domain.Tx, teamID string, exclude []string, at time.Time) ([]domainuser.User, error)
+}
+
+type ReassignmentEventRepository interface {
+	Append(ctx context.Context, tx domain.Tx, events []prdomain.AssignmentEvent) error
+}
+
+type ReassignmentTeamRepository interface {
+	GetByID(ctx context.Context, tx domain.Tx, id string) (*domainteam.Team, error)
 }
 
 type userReassignmentService struct {
-	prs   ReassignmentPRRepository
-	users ReassignmentUserRepository
-	clk   domain.Clock
-	strat domainuser.AssignmentStrategy
+	prs    ReassignmentPRRepository
+	users  ReassignmentUserRepository
+	teams  ReassignmentTeamRepository
+	events ReassignmentEventRepository
+	outbox webhook.Outbox
+	clk    domain.Clock
+	picker prdomain.ReviewerPicker
 }
 
-func NewUserReassignmentService(prs ReassignmentPRRepository, users ReassignmentUserRepository, clk domain.Clock, strat domainuser.AssignmentStrategy) domainuser.UserReassignmentService {
+func NewUserReassignmentService(prs ReassignmentPRRepository, users ReassignmentUserRepository, teams ReassignmentTeamRepository, events ReassignmentEventRepository, outbox webhook.Outbox, clk domain.Clock, strat domainuser.AssignmentStrategy, loads prdomain.ReviewLoadRepository) domainuser.UserReassignmentService {
 	return &userReassignmentService{
-		prs:   prs,
-		users: users,
-		clk:   clk,
-		strat: strat,
+		prs:    prs,
+		users:  users,
+		teams:  teams,
+		events: events,
+		outbox: outbox,
+		clk:    clk,
+		picker: prdomain.NewReviewerPicker(strat, loads),
 	}
 }
 
-func (s *userReassignmentService) ReassignUserInOpenPRs(ctx context.Context, tx domain.Tx, teamID string, u *domainuser.User) (int, error) {
+// ReassignUserInOpenPRs hands every open review of u to someone else, picked
+// like a manual reassignment: from u's team first, then from the fallback
+// teams of the PR author's team, under the author's team policy.
+func (s *userReassignmentService) ReassignUserInOpenPRs(ctx context.Context, tx domain.Tx, teamID string, u *domainuser.User, reason string) (int, error) {
 	open := prdomain.PRStatusOpen
 
-	prs, err := s.prs.ListAssignedTo(ctx, tx, u.ID, &open)
+	assigned, err := s.prs.ListAssignedTo(ctx, tx, u.ID, &open, domain.PageRequest{})
 	if err != nil {
 		return 0, err
 	}
+	prs := assigned.Items
 
-	baseCandidates, err := s.users.ListActiveByTeamExcept(ctx, tx, teamID, []string{u.ID})
+	reviewerTeam, err := s.teams.GetByID(ctx, tx, teamID)
 	if err != nil {
 		return 0, err
 	}
 
+	authorTeams := make(map[string]*domainteam.Team)
 	reassigned := 0
 
 	for _, pr := range prs {
-		exclude := pr.BuildExcludeList(u.ID)
-
-		cands := make([]domainuser.User, 0, len(baseCandidates))
-		for _, cand := range baseCandidates {
-			skip := false
-			for _, ex := range exclude {
-				if cand.ID == ex {
-					skip = true
-					break
-				}
-			}
-			if !skip {
-				cands = append(cands, cand)
+		tags, err := s.prs.RequiredTags(ctx, tx, pr.ID)
+		if err != nil {
+			return 0, err
+		}
+
+		authorTeam, ok := authorTeams[pr.AuthorID]
+		if !ok {
+			authorTeam, err = s.authorTeam(ctx, tx, pr.AuthorID)
+			if err != nil {
+				return 0, err
 			}
+			authorTeams[pr.AuthorID] = authorTeam
 		}
 
-		cand, err := s.strat.ChooseReassignment(ctx, *u, cands)
+		now := s.clk.Now()
+		if err := s.picker.BeginAssignment(ctx, tx, prdomain.ReplacementTeamIDs(reviewerTeam, authorTeam)); err != nil {
+			return 0, err
+		}
+		rep, err := prdomain.PickReplacementWithFallback(ctx, tx, s.users, s.picker, *u, reviewerTeam, authorTeam, pr.BuildExcludeList(u.ID), tags, now)
 		if err != nil {
 			return 0, err
 		}
 
-		now := s.clk.Now()
-		newReviewers, _ := pr.ReplaceReviewer(u.ID, cand.ID, now)
+		newReviewers, _ := pr.ReplaceReviewer(u.ID, rep.User.ID, now)
+		prdomain.MarkOverCapacity(newReviewers, map[string]bool{rep.User.ID: rep.Over})
 		prdomain.NormalizeReviewerSlots(newReviewers)
 
+		newReviewers, err = prdomain.FillVacantSlots(ctx, tx, s.picker, authorTeam.SaturationPolicy, pr.ID, newReviewers, authorTeam.RequiredReviewers, rep.Pool, tags, now)
+		if err != nil {
+			return 0, err
+		}
+		if err := s.picker.FinishAssignment(ctx, tx, rep.Assigned(newReviewers)); err != nil {
+			return 0, err
+		}
+		prdomain.MarkFallback(newReviewers, rep.Borrowed())
+
 		if err := s.prs.ReplaceReviewers(ctx, tx, pr.ID, newReviewers); err != nil {
 			return 0, err
 		}
+		events := prdomain.DiffReviewers(pr.ID, pr.Reviewers, newReviewers, domain.ActorFromContext(ctx), reason, now)
+		if err := s.events.Append(ctx, tx, events); err != nil {
+			return 0, err
+		}
+		if err := prdomain.PublishAssignmentEvents(ctx, tx, s.outbox, events); err != nil {
+			return 0, err
+		}
 		reassigned++
 	}
 
 	return reassigned, nil
 }
+
+func (s *userReassignmentService) authorTeam(ctx context.Context, tx domain.Tx, authorID string) (*domainteam.Team, error) {
+	author, err := s.users.GetByID(ctx, tx, authorID)
+	if err != nil {
+		return nil, err
+	}
+	return s.teams.GetByID(ctx, tx, author.TeamID)
+}
@@ -0,0 +1,91 @@
+package webhook
+
+import (
+	"time"
+)
+
+type EventType string
+
+const (
+	EventPRCreated          EventType = "pr.created"
+	EventReviewerAssigned   EventType = "reviewer.assigned"
+	EventReviewerReassigned EventType = "reviewer.reassigned"
+	EventPRMerged           EventType = "pr.merged"
+	EventPRReadyForReview   EventType = "pr.ready_for_review"
+	EventPRClosed           EventType = "pr.closed"
+	EventPRReopened         EventType = "pr.reopened"
+	EventUserDeactivated    EventType = "user.deactivated"
+)
+
+func ValidEventType(t EventType) bool {
+	switch t {
+	case EventPRCreated, EventReviewerAssigned, EventReviewerReassigned, EventPRMerged, EventUserDeactivated,
+		EventPRReadyForReview, EventPRClosed, EventPRReopened:
+		return true
+	default:
+		return false
+	}
+}
+
+type Subscription struct {
+	ID        string
+	URL       string
+	Secret    string
+	Events    []EventType
+	IsActive  bool
+	CreatedAt time.Time
+}
+
+type Event struct {
+	Type       EventType
+	Payload    any
+	OccurredAt time.Time
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "PENDING"
+	DeliveryDelivered DeliveryStatus = "DELIVERED"
+	DeliveryDead      DeliveryStatus = "DEAD"
+)
+
+type Delivery struct {
+	ID             int64
+	SubscriptionID string
+	URL            string
+	Secret         string
+	EventID        int64
+	EventType      EventType
+	Payload        []byte
+	OccurredAt     time.Time
+	Status         DeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastError      *string
+	DeliveredAt    *time.Time
+}
+
+type PRPayload struct {
+	PullRequestID     string   `json:"pull_request_id"`
+	PullRequestName   string   `json:"pull_request_name"`
+	AuthorID          string   `json:"author_id"`
+	Status            string   `json:"status"`
+	AssignedReviewers []string `json:"assigned_reviewers"`
+}
+
+type ReviewerPayload struct {
+	PullRequestID string  `json:"pull_request_id"`
+	Slot          int     `json:"slot"`
+	OldUserID     *string `json:"old_user_id,omitempty"`
+	NewUserID     string  `json:"new_user_id"`
+	Actor         string  `json:"actor"`
+	Reason        string  `json:"reason"`
+}
+
+type UserDeactivatedPayload struct {
+	UserID        string `json:"user_id"`
+	TeamID        string `json:"team_id"`
+	ReassignedPRs int    `json:"reassigned_prs"`
+	Actor         string `json:"actor"`
+}
@@ -0,0 +1,118 @@
+package webhook
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/user/reviewer-svc/internal/domain"
+)
+
+// Outbox stores events in the caller's transaction so they are delivered
+// only if the transaction commits.
+type Outbox interface {
+	Enqueue(ctx context.Context, tx domain.Tx, e Event) error
+}
+
+type Repository interface {
+	CreateSubscription(ctx context.Context, tx domain.Tx, s *Subscription) error
+	ListSubscriptions(ctx context.Context, tx domain.Tx) ([]Subscription, error)
+	DeleteSubscription(ctx context.Context, tx domain.Tx, id string) error
+	ListDeadLetters(ctx context.Context, tx domain.Tx) ([]Delivery, error)
+	RetryDelivery(ctx context.Context, tx domain.Tx, id int64, at time.Time) error
+}
+
+type DeliveryRepository interface {
+	ClaimDue(ctx context.Context, tx domain.Tx, now time.Time, leaseUntil time.Time, limit int) ([]Delivery, error)
+	MarkDelivered(ctx context.Context, tx domain.Tx, id int64, at time.Time) error
+	MarkFailed(ctx context.Context, tx domain.Tx, id int64, attempts int, nextAttemptAt time.Time, lastErr string, dead bool) error
+}
+
+type WebhookService struct {
+	repo  Repository
+	tx    domain.TxManager
+	clk   domain.Clock
+	idGen domain.IDGenerator
+}
+
+func NewWebhookService(repo Repository, tx domain.TxManager, clk domain.Clock, idGen domain.IDGenerator) *WebhookService {
+	return &WebhookService{repo: repo, tx: tx, clk: clk, idGen: idGen}
+}
+
+func (s WebhookService) CreateSubscription(ctx context.Context, rawURL, secret string, events []EventType) (*Subscription, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, domain.ErrInvalidWebhookURL
+	}
+	if secret == "" || len(events) == 0 {
+		return nil, domain.ErrInvalidRequest
+	}
+
+	uniq := make([]EventType, 0, len(events))
+	seen := make(map[EventType]struct{}, len(events))
+	for _, e := range events {
+		if !ValidEventType(e) {
+			return nil, domain.ErrInvalidWebhookEvent
+		}
+		if _, ok := seen[e]; ok {
+			continue
+		}
+		seen[e] = struct{}{}
+		uniq = append(uniq, e)
+	}
+
+	sub := &Subscription{
+		ID:        s.idGen.Generate(),
+		URL:       rawURL,
+		Secret:    secret,
+		Events:    uniq,
+		IsActive:  true,
+		CreatedAt: s.clk.Now(),
+	}
+
+	err = s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
+		return s.repo.CreateSubscription(ctx, ttx, sub)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+func (s WebhookService) ListSubscriptions(ctx context.Context) ([]Subscription, error) {
+	var res []Subscription
+	err := s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
+		list, err := s.repo.ListSubscriptions(ctx, ttx)
+		if err != nil {
+			return err
+		}
+		res = list
+		return nil
+	}, domain.ReadOnly())
+	return res, err
+}
+
+func (s WebhookService) DeleteSubscription(ctx context.Context, id string) error {
+	return s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
+		return s.repo.DeleteSubscription(ctx, ttx, id)
+	})
+}
+
+func (s WebhookService) ListDeadLetters(ctx context.Context) ([]Delivery, error) {
+	var res []Delivery
+	err := s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
+		list, err := s.repo.ListDeadLetters(ctx, ttx)
+		if err != nil {
+			return err
+		}
+		res = list
+		return nil
+	}, domain.ReadOnly())
+	return res, err
+}
+
+func (s WebhookService) RetryDeadLetter(ctx context.Context, id int64) error {
+	return s.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
+		return s.repo.RetryDelivery(ctx, ttx, id, s.clk.Now())
+	})
+}
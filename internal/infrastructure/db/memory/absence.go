@@ -0,0 +1,113 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	domain "github.com/user/reviewer-svc/internal/domain"
+	domainuser "github.com/user/reviewer-svc/internal/domain/user"
+)
+
+type AbsenceRepo struct{}
+
+func NewAbsenceRepo() *AbsenceRepo {
+	return &AbsenceRepo{}
+}
+
+func (r *AbsenceRepo) Create(ctx context.Context, ttx domain.Tx, a *domainuser.Absence) error {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return err
+	}
+	if _, ok := tx.data.absences[a.ID]; ok {
+		return domain.ErrAlreadyExists
+	}
+	if _, ok := tx.data.users[a.UserID]; !ok {
+		return domain.ErrConstraintViolation
+	}
+	writable(tx, &tx.data.absences)[a.ID] = *a
+	return nil
+}
+
+// GetByID ignores forUpdate: transactions never run concurrently.
+func (r *AbsenceRepo) GetByID(ctx context.Context, ttx domain.Tx, id string, forUpdate bool) (*domainuser.Absence, error) {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return nil, err
+	}
+	a, ok := tx.data.absences[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return &a, nil
+}
+
+func (r *AbsenceRepo) Update(ctx context.Context, ttx domain.Tx, a *domainuser.Absence) error {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return err
+	}
+	stored, ok := tx.data.absences[a.ID]
+	if !ok {
+		return domain.ErrNotFound
+	}
+	stored.StartsAt = a.StartsAt
+	stored.EndsAt = a.EndsAt
+	stored.Reason = a.Reason
+	stored.ReassignOpenPRs = a.ReassignOpenPRs
+	stored.ReassignedAt = a.ReassignedAt
+	writable(tx, &tx.data.absences)[a.ID] = stored
+	return nil
+}
+
+func (r *AbsenceRepo) Delete(ctx context.Context, ttx domain.Tx, id string) error {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return err
+	}
+	if _, ok := tx.data.absences[id]; !ok {
+		return domain.ErrNotFound
+	}
+	delete(writable(tx, &tx.data.absences), id)
+	return nil
+}
+
+func (r *AbsenceRepo) ListByUser(ctx context.Context, ttx domain.Tx, userID string) ([]domainuser.Absence, error) {
+	return r.list(ttx, 0, func(a domainuser.Absence) bool {
+		return a.UserID == userID
+	})
+}
+
+func (r *AbsenceRepo) ListReassignmentDue(ctx context.Context, ttx domain.Tx, now time.Time, limit int) ([]domainuser.Absence, error) {
+	return r.list(ttx, limit, func(a domainuser.Absence) bool {
+		return a.ReassignmentDue(now)
+	})
+}
+
+// list returns the matching absences ordered by (starts_at, id); limit 0
+// means all of them.
+func (r *AbsenceRepo) list(ttx domain.Tx, limit int, match func(domainuser.Absence) bool) ([]domainuser.Absence, error) {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return nil, err
+	}
+	var res []domainuser.Absence
+	for _, a := range tx.data.absences {
+		if match(a) {
+			res = append(res, a)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if !res[i].StartsAt.Equal(res[j].StartsAt) {
+			return res[i].StartsAt.Before(res[j].StartsAt)
+		}
+		return res[i].ID < res[j].ID
+	})
+	if limit > 0 && len(res) > limit {
+		res = res[:limit]
+	}
+	return res, nil
+}
+
+var _ domainuser.AbsenceRepository = (*AbsenceRepo)(nil)
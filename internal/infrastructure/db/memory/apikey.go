@@ -0,0 +1,89 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	domain "github.com/user/reviewer-svc/internal/domain"
+	domainapikey "github.com/user/reviewer-svc/internal/domain/apikey"
+)
+
+type APIKeyRepo struct{}
+
+func NewAPIKeyRepo() *APIKeyRepo {
+	return &APIKeyRepo{}
+}
+
+func (r *APIKeyRepo) Create(ctx context.Context, ttx domain.Tx, k *domainapikey.APIKey) error {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return err
+	}
+	for id, stored := range tx.data.apiKeys {
+		if id == k.ID || stored.Name == k.Name || stored.Hash == k.Hash {
+			return domain.ErrAlreadyExists
+		}
+	}
+	writable(tx, &tx.data.apiKeys)[k.ID] = *k
+	return nil
+}
+
+func (r *APIKeyRepo) GetByID(ctx context.Context, ttx domain.Tx, id string) (*domainapikey.APIKey, error) {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return nil, err
+	}
+	k, ok := tx.data.apiKeys[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return &k, nil
+}
+
+func (r *APIKeyRepo) GetByHash(ctx context.Context, ttx domain.Tx, hash string) (*domainapikey.APIKey, error) {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range tx.data.apiKeys {
+		if k.Hash == hash {
+			return &k, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (r *APIKeyRepo) List(ctx context.Context, ttx domain.Tx) ([]domainapikey.APIKey, error) {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domainapikey.APIKey, 0, len(tx.data.apiKeys))
+	for _, k := range tx.data.apiKeys {
+		res = append(res, k)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if !res[i].CreatedAt.Equal(res[j].CreatedAt) {
+			return res[i].CreatedAt.Before(res[j].CreatedAt)
+		}
+		return res[i].ID < res[j].ID
+	})
+	return res, nil
+}
+
+func (r *APIKeyRepo) Revoke(ctx context.Context, ttx domain.Tx, id string, at time.Time) error {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return err
+	}
+	k, ok := tx.data.apiKeys[id]
+	if !ok {
+		return domain.ErrNotFound
+	}
+	k.RevokedAt = &at
+	writable(tx, &tx.data.apiKeys)[id] = k
+	return nil
+}
+
+var _ domainapikey.Repository = (*APIKeyRepo)(nil)
@@ -0,0 +1,40 @@
+package memory
+
+import (
+	"context"
+
+	domain "github.com/user/reviewer-svc/internal/domain"
+	domaincodeowners "github.com/user/reviewer-svc/internal/domain/codeowners"
+)
+
+type CodeownersRepo struct{}
+
+func NewCodeownersRepo() *CodeownersRepo {
+	return &CodeownersRepo{}
+}
+
+func (r *CodeownersRepo) Get(ctx context.Context, ttx domain.Tx, teamID string) (*domaincodeowners.Ruleset, error) {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return nil, err
+	}
+	rs, ok := tx.data.codeowners[teamID]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return &rs, nil
+}
+
+func (r *CodeownersRepo) Save(ctx context.Context, ttx domain.Tx, rs *domaincodeowners.Ruleset) error {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return err
+	}
+	if _, ok := tx.data.teams[rs.TeamID]; !ok {
+		return domain.ErrConstraintViolation
+	}
+	writable(tx, &tx.data.codeowners)[rs.TeamID] = *rs
+	return nil
+}
+
+var _ domaincodeowners.Repository = (*CodeownersRepo)(nil)
@@ -0,0 +1,50 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	domain "github.com/user/reviewer-svc/internal/domain"
+	domainpr "github.com/user/reviewer-svc/internal/domain/pr"
+	userreassign "github.com/user/reviewer-svc/internal/domain/userreassign"
+)
+
+type HistoryRepo struct{}
+
+func NewHistoryRepo() *HistoryRepo {
+	return &HistoryRepo{}
+}
+
+func (r *HistoryRepo) Append(ctx context.Context, ttx domain.Tx, events []domainpr.AssignmentEvent) error {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return err
+	}
+	for _, e := range events {
+		if _, ok := tx.data.prs[e.PRID]; !ok {
+			return domain.ErrConstraintViolation
+		}
+		tx.data.lastEvent++
+		e.ID = tx.data.lastEvent
+		writable(tx, &tx.data.events)[e.ID] = e
+	}
+	return nil
+}
+
+func (r *HistoryRepo) ListByPR(ctx context.Context, ttx domain.Tx, prID string) ([]domainpr.AssignmentEvent, error) {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return nil, err
+	}
+	var res []domainpr.AssignmentEvent
+	for _, e := range tx.data.events {
+		if e.PRID == prID {
+			res = append(res, e)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ID < res[j].ID })
+	return res, nil
+}
+
+var _ domainpr.AssignmentEventRepository = (*HistoryRepo)(nil)
+var _ userreassign.ReassignmentEventRepository = (*HistoryRepo)(nil)
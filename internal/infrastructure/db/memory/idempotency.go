@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	domain "github.com/user/reviewer-svc/internal/domain"
+	domainidempotency "github.com/user/reviewer-svc/internal/domain/idempotency"
+)
+
+type IdempotencyRepo struct{}
+
+func NewIdempotencyRepo() *IdempotencyRepo {
+	return &IdempotencyRepo{}
+}
+
+func (r *IdempotencyRepo) Create(ctx context.Context, ttx domain.Tx, rec *domainidempotency.Record) error {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return err
+	}
+	k := idempotencyKey{scope: rec.Scope, key: rec.Key}
+	if _, ok := tx.data.idemKeys[k]; ok {
+		return domain.ErrAlreadyExists
+	}
+	writable(tx, &tx.data.idemKeys)[k] = *rec
+	return nil
+}
+
+func (r *IdempotencyRepo) Get(ctx context.Context, ttx domain.Tx, scope, key string, forUpdate bool) (*domainidempotency.Record, error) {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return nil, err
+	}
+	rec, ok := tx.data.idemKeys[idempotencyKey{scope: scope, key: key}]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return &rec, nil
+}
+
+func (r *IdempotencyRepo) Update(ctx context.Context, ttx domain.Tx, rec *domainidempotency.Record) error {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return err
+	}
+	k := idempotencyKey{scope: rec.Scope, key: rec.Key}
+	if _, ok := tx.data.idemKeys[k]; !ok {
+		return domain.ErrNotFound
+	}
+	writable(tx, &tx.data.idemKeys)[k] = *rec
+	return nil
+}
+
+func (r *IdempotencyRepo) Delete(ctx context.Context, ttx domain.Tx, scope, key string) error {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return err
+	}
+	k := idempotencyKey{scope: scope, key: key}
+	if _, ok := tx.data.idemKeys[k]; !ok {
+		return domain.ErrNotFound
+	}
+	delete(writable(tx, &tx.data.idemKeys), k)
+	return nil
+}
+
+func (r *IdempotencyRepo) DeleteExpired(ctx context.Context, ttx domain.Tx, now time.Time, limit int) (int, error) {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for k, rec := range tx.data.idemKeys {
+		if n == limit {
+			break
+		}
+		if !rec.ExpiresAt.After(now) {
+			delete(writable(tx, &tx.data.idemKeys), k)
+			n++
+		}
+	}
+	return n, nil
+}
+
+var _ domainidempotency.Repository = (*IdempotencyRepo)(nil)
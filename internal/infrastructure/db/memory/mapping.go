@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	domain "github.com/user/reviewer-svc/internal/domain"
+	domainintegration "github.com/user/reviewer-svc/internal/domain/integration"
+)
+
+type MappingRepo struct{}
+
+func NewMappingRepo() *MappingRepo {
+	return &MappingRepo{}
+}
+
+func (r *MappingRepo) Upsert(ctx context.Context, ttx domain.Tx, m *domainintegration.UserMapping) error {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return err
+	}
+	if _, ok := tx.data.users[m.UserID]; !ok {
+		return domain.ErrConstraintViolation
+	}
+	key := mappingKey{provider: m.Provider, login: m.ExternalLogin}
+	stored, ok := tx.data.mappings[key]
+	if !ok {
+		stored = *m
+	}
+	stored.UserID = m.UserID
+	writable(tx, &tx.data.mappings)[key] = stored
+	return nil
+}
+
+func (r *MappingRepo) Get(ctx context.Context, ttx domain.Tx, provider, login string) (*domainintegration.UserMapping, error) {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := tx.data.mappings[mappingKey{provider: provider, login: login}]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return &m, nil
+}
+
+func (r *MappingRepo) List(ctx context.Context, ttx domain.Tx, provider string) ([]domainintegration.UserMapping, error) {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return nil, err
+	}
+	var res []domainintegration.UserMapping
+	for _, m := range tx.data.mappings {
+		if m.Provider == provider {
+			res = append(res, m)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ExternalLogin < res[j].ExternalLogin })
+	return res, nil
+}
+
+var _ domainintegration.MappingRepository = (*MappingRepo)(nil)
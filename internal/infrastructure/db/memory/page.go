@@ -0,0 +1,38 @@
+package memory
+
+import (
+	"sort"
+
+	domain "github.com/user/reviewer-svc/internal/domain"
+)
+
+// paginate mirrors the keyset queries of the SQL backend: rows are ordered by
+// (created_at, id), those up to and including the cursor are skipped and one
+// extra row is kept so that domain.NewPage can tell whether more follow.
+func paginate[T any](rows []T, page domain.PageRequest, desc bool, key func(T) domain.Cursor) domain.Page[T] {
+	sort.Slice(rows, func(i, j int) bool {
+		return cursorLess(key(rows[i]), key(rows[j])) != desc
+	})
+
+	if page.Cursor != nil {
+		kept := rows[:0]
+		for _, r := range rows {
+			k := key(r)
+			if (desc && cursorLess(k, *page.Cursor)) || (!desc && cursorLess(*page.Cursor, k)) {
+				kept = append(kept, r)
+			}
+		}
+		rows = kept
+	}
+	if page.Limit > 0 && len(rows) > page.Limit+1 {
+		rows = rows[:page.Limit+1]
+	}
+	return domain.NewPage(rows, page, key)
+}
+
+func cursorLess(a, b domain.Cursor) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.Before(b.CreatedAt)
+	}
+	return a.ID < b.ID
+}
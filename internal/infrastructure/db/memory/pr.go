@@ -0,0 +1,313 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	domain "github.com/user/reviewer-svc/internal/domain"
+	domainpr "github.com/user/reviewer-svc/internal/domain/pr"
+	stats "github.com/user/reviewer-svc/internal/domain/stats"
+	userreassign "github.com/user/reviewer-svc/internal/domain/userreassign"
+)
+
+type PRRepo struct{}
+
+func NewPRRepo() *PRRepo {
+	return &PRRepo{}
+}
+
+var (
+	_ domainpr.PullRequestRepository        = (*PRRepo)(nil)
+	_ domainpr.ReviewLoadRepository         = (*PRRepo)(nil)
+	_ stats.PullRequestStatsRepository      = (*PRRepo)(nil)
+	_ userreassign.ReassignmentPRRepository = (*PRRepo)(nil)
+)
+
+func (r *PRRepo) Create(ctx context.Context, ttx domain.Tx, pr *domainpr.PullRequest) error {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return err
+	}
+	if _, ok := tx.data.prs[pr.ID]; ok {
+		return domain.ErrAlreadyExists
+	}
+	if !domainpr.ValidStatus(pr.Status) {
+		return fmt.Errorf("unknown PR status %q", pr.Status)
+	}
+	if _, ok := tx.data.users[pr.AuthorID]; !ok {
+		return domain.ErrConstraintViolation
+	}
+	if err := checkReviewers(tx.data, pr.Reviewers); err != nil {
+		return err
+	}
+
+	stored := *pr
+	stored.Reviewers = cloneReviewers(pr.ID, pr.Reviewers)
+	writable(tx, &tx.data.prs)[pr.ID] = stored
+	return nil
+}
+
+// GetByID ignores forUpdate: transactions never run concurrently.
+func (r *PRRepo) GetByID(ctx context.Context, ttx domain.Tx, id string, forUpdate bool) (*domainpr.PullRequest, error) {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return nil, err
+	}
+	pr, ok := tx.data.prs[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	pr.Reviewers = cloneReviewers(pr.ID, pr.Reviewers)
+	return &pr, nil
+}
+
+func (r *PRRepo) UpdateStatus(ctx context.Context, ttx domain.Tx, id string, status domainpr.PRStatus, mergedAt *time.Time) error {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return err
+	}
+	if !domainpr.ValidStatus(status) {
+		return fmt.Errorf("unknown PR status %q", status)
+	}
+	pr, ok := tx.data.prs[id]
+	if !ok {
+		return nil
+	}
+	pr.Status = status
+	pr.MergedAt = mergedAt
+	writable(tx, &tx.data.prs)[id] = pr
+	return nil
+}
+
+func (r *PRRepo) ReplaceReviewers(ctx context.Context, ttx domain.Tx, prID string, reviewers []domainpr.PRReviewer) error {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return err
+	}
+	pr, ok := tx.data.prs[prID]
+	if !ok {
+		return domain.ErrConstraintViolation
+	}
+	if err := checkReviewers(tx.data, reviewers); err != nil {
+		return err
+	}
+	pr.Reviewers = cloneReviewers(prID, reviewers)
+	writable(tx, &tx.data.prs)[prID] = pr
+	return nil
+}
+
+func (r *PRRepo) SetReviewVerdict(ctx context.Context, ttx domain.Tx, prID, userID string, verdict domainpr.ReviewVerdict, reviewedAt time.Time) error {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return err
+	}
+	pr, ok := tx.data.prs[prID]
+	if !ok {
+		return domain.ErrBadReviewer
+	}
+	pr.Reviewers = cloneReviewers(prID, pr.Reviewers)
+	i := slices.IndexFunc(pr.Reviewers, func(rv domainpr.PRReviewer) bool { return rv.UserID == userID })
+	if i < 0 {
+		return domain.ErrBadReviewer
+	}
+	pr.Reviewers[i].Verdict = verdict
+	pr.Reviewers[i].ReviewedAt = &reviewedAt
+	writable(tx, &tx.data.prs)[prID] = pr
+	return nil
+}
+
+func (r *PRRepo) SetChangedFiles(ctx context.Context, ttx domain.Tx, prID string, paths []string) error {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return err
+	}
+	if _, ok := tx.data.prs[prID]; !ok {
+		return domain.ErrConstraintViolation
+	}
+	writable(tx, &tx.data.changed)[prID] = slices.Sorted(slices.Values(paths))
+	return nil
+}
+
+func (r *PRRepo) ChangedFiles(ctx context.Context, ttx domain.Tx, prID string) ([]string, error) {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return nil, err
+	}
+	return slices.Clone(tx.data.changed[prID]), nil
+}
+
+func (r *PRRepo) SetRequiredTags(ctx context.Context, ttx domain.Tx, prID string, tags []string) error {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return err
+	}
+	if _, ok := tx.data.prs[prID]; !ok {
+		return domain.ErrConstraintViolation
+	}
+	writable(tx, &tx.data.required)[prID] = slices.Sorted(slices.Values(tags))
+	return nil
+}
+
+func (r *PRRepo) RequiredTags(ctx context.Context, ttx domain.Tx, prID string) ([]string, error) {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return nil, err
+	}
+	return slices.Clone(tx.data.required[prID]), nil
+}
+
+func (r *PRRepo) List(ctx context.Context, ttx domain.Tx, status *domainpr.PRStatus, page domain.PageRequest) (domain.Page[domainpr.PullRequest], error) {
+	return r.list(ttx, page, func(pr domainpr.PullRequest) bool {
+		return status == nil || pr.Status == *status
+	})
+}
+
+func (r *PRRepo) ListAssignedTo(ctx context.Context, ttx domain.Tx, userID string, status *domainpr.PRStatus, page domain.PageRequest) (domain.Page[domainpr.PullRequest], error) {
+	return r.list(ttx, page, func(pr domainpr.PullRequest) bool {
+		return (status == nil || pr.Status == *status) && hasReviewer(pr, userID)
+	})
+}
+
+// list pages through the matching PRs newest first.
+func (r *PRRepo) list(ttx domain.Tx, page domain.PageRequest, match func(domainpr.PullRequest) bool) (domain.Page[domainpr.PullRequest], error) {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return domain.Page[domainpr.PullRequest]{}, err
+	}
+	var res []domainpr.PullRequest
+	for _, pr := range tx.data.prs {
+		if match(pr) {
+			pr.Reviewers = cloneReviewers(pr.ID, pr.Reviewers)
+			res = append(res, pr)
+		}
+	}
+	return paginate(res, page, true, prCursor), nil
+}
+
+// CountOpenReviews returns the number of OPEN PRs each of userIDs reviews.
+// Users without open reviews are absent from the result.
+func (r *PRRepo) CountOpenReviews(ctx context.Context, ttx domain.Tx, userIDs []string) (map[string]int, error) {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]int, len(userIDs))
+	for _, pr := range tx.data.prs {
+		if pr.Status != domainpr.PRStatusOpen {
+			continue
+		}
+		for _, rv := range pr.Reviewers {
+			if slices.Contains(userIDs, rv.UserID) {
+				res[rv.UserID]++
+			}
+		}
+	}
+	return res, nil
+}
+
+func (r *PRRepo) StatsByUser(ctx context.Context, ttx domain.Tx, teamID *string) ([]stats.UserAssignmentsStats, error) {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return nil, err
+	}
+
+	byUser := make(map[string]*stats.UserAssignmentsStats)
+	for _, u := range tx.data.users {
+		if teamID == nil || u.TeamID == *teamID {
+			byUser[u.ID] = &stats.UserAssignmentsStats{UserID: u.ID}
+		}
+	}
+	for _, pr := range tx.data.prs {
+		for _, rv := range pr.Reviewers {
+			s, ok := byUser[rv.UserID]
+			if !ok {
+				continue
+			}
+			s.TotalAssigned++
+			switch pr.Status {
+			case domainpr.PRStatusOpen:
+				s.OpenAssigned++
+			case domainpr.PRStatusMerged:
+				s.MergedAssigned++
+			}
+		}
+	}
+
+	res := make([]stats.UserAssignmentsStats, 0, len(byUser))
+	for _, s := range byUser {
+		res = append(res, *s)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].UserID < res[j].UserID })
+	return res, nil
+}
+
+func (r *PRRepo) StatsByPR(ctx context.Context, ttx domain.Tx, teamID *string) ([]stats.PRAssignmentsStats, error) {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []stats.PRAssignmentsStats
+	for _, pr := range tx.data.prs {
+		if teamID != nil {
+			author, ok := tx.data.users[pr.AuthorID]
+			if !ok || author.TeamID != *teamID {
+				continue
+			}
+		}
+		res = append(res, stats.PRAssignmentsStats{PRID: pr.ID, ReviewersCount: len(pr.Reviewers)})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].PRID < res[j].PRID })
+	return res, nil
+}
+
+// checkReviewers enforces the pr_reviewers constraints of the SQL schema.
+func checkReviewers(s *state, reviewers []domainpr.PRReviewer) error {
+	slots := make(map[int]bool, len(reviewers))
+	users := make(map[string]bool, len(reviewers))
+	for _, rv := range reviewers {
+		if rv.Slot < 1 {
+			return domain.ErrConstraintViolation
+		}
+		if _, ok := s.users[rv.UserID]; !ok {
+			return domain.ErrConstraintViolation
+		}
+		if slots[rv.Slot] || users[rv.UserID] {
+			return domain.ErrAlreadyExists
+		}
+		slots[rv.Slot] = true
+		users[rv.UserID] = true
+	}
+	return nil
+}
+
+// cloneReviewers copies reviewers ordered by slot so that callers never share
+// a slice with the stored PR.
+func cloneReviewers(prID string, reviewers []domainpr.PRReviewer) []domainpr.PRReviewer {
+	if len(reviewers) == 0 {
+		return nil
+	}
+	res := make([]domainpr.PRReviewer, len(reviewers))
+	copy(res, reviewers)
+	for i := range res {
+		res[i].PRID = prID
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Slot < res[j].Slot })
+	return res
+}
+
+func hasReviewer(pr domainpr.PullRequest, userID string) bool {
+	for _, rv := range pr.Reviewers {
+		if rv.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+func prCursor(pr domainpr.PullRequest) domain.Cursor {
+	return domain.Cursor{CreatedAt: pr.CreatedAt, ID: pr.ID}
+}
@@ -0,0 +1,44 @@
+package memory
+
+import (
+	"context"
+
+	domain "github.com/user/reviewer-svc/internal/domain"
+	domainuser "github.com/user/reviewer-svc/internal/domain/user"
+)
+
+type RotationRepo struct{}
+
+func NewRotationRepo() *RotationRepo {
+	return &RotationRepo{}
+}
+
+// LockCursor takes no lock: transactions never run concurrently.
+func (r *RotationRepo) LockCursor(ctx context.Context, ttx domain.Tx, teamID string) (domainuser.RotationCursor, error) {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return domainuser.RotationCursor{}, err
+	}
+	if _, ok := tx.data.teams[teamID]; !ok {
+		return domainuser.RotationCursor{}, domain.ErrConstraintViolation
+	}
+	c, ok := tx.data.rotation[teamID]
+	if !ok {
+		return domainuser.RotationCursor{TeamID: teamID}, nil
+	}
+	return c, nil
+}
+
+func (r *RotationRepo) SaveCursor(ctx context.Context, ttx domain.Tx, c domainuser.RotationCursor) error {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return err
+	}
+	if _, ok := tx.data.teams[c.TeamID]; !ok {
+		return domain.ErrConstraintViolation
+	}
+	writable(tx, &tx.data.rotation)[c.TeamID] = c
+	return nil
+}
+
+var _ domainuser.RotationRepository = (*RotationRepo)(nil)
@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"context"
+	"slices"
+
+	domain "github.com/user/reviewer-svc/internal/domain"
+	domainuser "github.com/user/reviewer-svc/internal/domain/user"
+)
+
+type TagRepo struct{}
+
+func NewTagRepo() *TagRepo {
+	return &TagRepo{}
+}
+
+// ListByUsers maps each of userIDs that has tags to them, sorted.
+func (r *TagRepo) ListByUsers(ctx context.Context, ttx domain.Tx, userIDs []string) (map[string][]string, error) {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string][]string)
+	for _, id := range userIDs {
+		if tags := tx.data.tags[id]; len(tags) > 0 {
+			res[id] = slices.Clone(tags)
+		}
+	}
+	return res, nil
+}
+
+func (r *TagRepo) Add(ctx context.Context, ttx domain.Tx, userID string, tags []string) error {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return err
+	}
+	if _, ok := tx.data.users[userID]; !ok {
+		return domain.ErrConstraintViolation
+	}
+	merged := slices.Concat(tx.data.tags[userID], tags)
+	slices.Sort(merged)
+	writable(tx, &tx.data.tags)[userID] = slices.Compact(merged)
+	return nil
+}
+
+func (r *TagRepo) Replace(ctx context.Context, ttx domain.Tx, userID string, tags []string) error {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return err
+	}
+	if _, ok := tx.data.users[userID]; !ok {
+		return domain.ErrConstraintViolation
+	}
+	if len(tags) == 0 {
+		delete(writable(tx, &tx.data.tags), userID)
+		return nil
+	}
+	writable(tx, &tx.data.tags)[userID] = slices.Sorted(slices.Values(tags))
+	return nil
+}
+
+func (r *TagRepo) Remove(ctx context.Context, ttx domain.Tx, userID, tag string) error {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return err
+	}
+	tags := tx.data.tags[userID]
+	if !slices.Contains(tags, tag) {
+		return domain.ErrNotFound
+	}
+	writable(tx, &tx.data.tags)[userID] = slices.DeleteFunc(slices.Clone(tags), func(t string) bool { return t == tag })
+	return nil
+}
+
+var _ domainuser.TagRepository = (*TagRepo)(nil)
@@ -0,0 +1,124 @@
+package memory
+
+import (
+	"context"
+	"slices"
+
+	domain "github.com/user/reviewer-svc/internal/domain"
+	domainteam "github.com/user/reviewer-svc/internal/domain/team"
+)
+
+type TeamRepo struct{}
+
+func NewTeamRepo() *TeamRepo {
+	return &TeamRepo{}
+}
+
+func (r *TeamRepo) Create(ctx context.Context, ttx domain.Tx, t *domainteam.Team) error {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return err
+	}
+	if _, ok := tx.data.teams[t.ID]; ok {
+		return domain.ErrAlreadyExists
+	}
+	if _, ok := findTeamByName(tx.data, t.Name); ok {
+		return domain.ErrAlreadyExists
+	}
+	writable(tx, &tx.data.teams)[t.ID] = *t
+	return nil
+}
+
+func (r *TeamRepo) GetByID(ctx context.Context, ttx domain.Tx, id string) (*domainteam.Team, error) {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return nil, err
+	}
+	t, ok := tx.data.teams[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	t = withFallbacks(tx.data, t)
+	return &t, nil
+}
+
+func (r *TeamRepo) Update(ctx context.Context, ttx domain.Tx, t *domainteam.Team) error {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return err
+	}
+	if _, ok := tx.data.teams[t.ID]; !ok {
+		return domain.ErrNotFound
+	}
+	if other, ok := findTeamByName(tx.data, t.Name); ok && other.ID != t.ID {
+		return domain.ErrAlreadyExists
+	}
+	writable(tx, &tx.data.teams)[t.ID] = *t
+	return nil
+}
+
+func (r *TeamRepo) SetFallbacks(ctx context.Context, ttx domain.Tx, teamID string, fallbackIDs []string) error {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return err
+	}
+	if _, ok := tx.data.teams[teamID]; !ok {
+		return domain.ErrNotFound
+	}
+	for _, id := range fallbackIDs {
+		if _, ok := tx.data.teams[id]; !ok || id == teamID {
+			return domain.ErrConstraintViolation
+		}
+	}
+	writable(tx, &tx.data.fallbacks)[teamID] = slices.Clone(fallbackIDs)
+	return nil
+}
+
+func (r *TeamRepo) GetByName(ctx context.Context, ttx domain.Tx, name string) (*domainteam.Team, error) {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return nil, err
+	}
+	t, ok := findTeamByName(tx.data, name)
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	t = withFallbacks(tx.data, t)
+	return &t, nil
+}
+
+func (r *TeamRepo) List(ctx context.Context, ttx domain.Tx, page domain.PageRequest) (domain.Page[domainteam.Team], error) {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return domain.Page[domainteam.Team]{}, err
+	}
+	res := make([]domainteam.Team, 0, len(tx.data.teams))
+	for _, t := range tx.data.teams {
+		res = append(res, withFallbacks(tx.data, t))
+	}
+	return paginate(res, page, false, teamCursor), nil
+}
+
+func findTeamByName(s *state, name string) (domainteam.Team, bool) {
+	for _, t := range s.teams {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return domainteam.Team{}, false
+}
+
+// withFallbacks fills in the fallback teams of t, as the SQL backends do.
+func withFallbacks(s *state, t domainteam.Team) domainteam.Team {
+	t.FallbackTeams = nil
+	for _, id := range s.fallbacks[t.ID] {
+		t.FallbackTeams = append(t.FallbackTeams, domainteam.TeamRef{ID: id, Name: s.teams[id].Name})
+	}
+	return t
+}
+
+func teamCursor(t domainteam.Team) domain.Cursor {
+	return domain.Cursor{CreatedAt: t.CreatedAt, ID: t.ID}
+}
+
+var _ domainteam.Repository = (*TeamRepo)(nil)
@@ -0,0 +1,167 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"sync"
+
+	domain "github.com/user/reviewer-svc/internal/domain"
+	domainapikey "github.com/user/reviewer-svc/internal/domain/apikey"
+	domaincodeowners "github.com/user/reviewer-svc/internal/domain/codeowners"
+	domainidempotency "github.com/user/reviewer-svc/internal/domain/idempotency"
+	domainintegration "github.com/user/reviewer-svc/internal/domain/integration"
+	domainpr "github.com/user/reviewer-svc/internal/domain/pr"
+	domainteam "github.com/user/reviewer-svc/internal/domain/team"
+	domainuser "github.com/user/reviewer-svc/internal/domain/user"
+	domainwebhook "github.com/user/reviewer-svc/internal/domain/webhook"
+	"github.com/user/reviewer-svc/internal/infrastructure/tracing"
+)
+
+// errNoSQL is returned when something tries to run SQL on a memory
+// transaction: the repositories in this package never do.
+var errNoSQL = errors.New("memory: SQL is not supported by the in-memory backend")
+
+var errForeignTx = errors.New("memory: transaction does not belong to the in-memory backend")
+
+type mappingKey struct {
+	provider string
+	login    string
+}
+
+type idempotencyKey struct {
+	scope string
+	key   string
+}
+
+// state is one version of every table. Maps are shared between versions
+// until a transaction writes to them; see Tx.
+type state struct {
+	teams      map[string]domainteam.Team
+	fallbacks  map[string][]string
+	codeowners map[string]domaincodeowners.Ruleset
+	rotation   map[string]domainuser.RotationCursor
+	users      map[string]domainuser.User
+	tags       map[string][]string
+	prs        map[string]domainpr.PullRequest
+	changed    map[string][]string
+	required   map[string][]string
+	events     map[int64]domainpr.AssignmentEvent
+	absences   map[string]domainuser.Absence
+	mappings   map[mappingKey]domainintegration.UserMapping
+	subs       map[string]domainwebhook.Subscription
+	outbox     map[int64]outboxEntry
+	delivery   map[int64]domainwebhook.Delivery
+	apiKeys    map[string]domainapikey.APIKey
+	idemKeys   map[idempotencyKey]domainidempotency.Record
+	lastEvent  int64
+	lastOut    int64
+	lastDeliv  int64
+}
+
+func newState() *state {
+	return &state{
+		teams:      map[string]domainteam.Team{},
+		fallbacks:  map[string][]string{},
+		codeowners: map[string]domaincodeowners.Ruleset{},
+		rotation:   map[string]domainuser.RotationCursor{},
+		users:      map[string]domainuser.User{},
+		tags:       map[string][]string{},
+		prs:        map[string]domainpr.PullRequest{},
+		changed:    map[string][]string{},
+		required:   map[string][]string{},
+		events:     map[int64]domainpr.AssignmentEvent{},
+		absences:   map[string]domainuser.Absence{},
+		mappings:   map[mappingKey]domainintegration.UserMapping{},
+		subs:       map[string]domainwebhook.Subscription{},
+		outbox:     map[int64]outboxEntry{},
+		delivery:   map[int64]domainwebhook.Delivery{},
+		apiKeys:    map[string]domainapikey.APIKey{},
+		idemKeys:   map[idempotencyKey]domainidempotency.Record{},
+	}
+}
+
+// TxManager runs transactions one at a time against an in-memory copy of
+// the data. It is meant for tests and local demos; nothing is persisted.
+type TxManager struct {
+	mu   sync.Mutex
+	data *state
+}
+
+func NewTxManager() *TxManager {
+	return &TxManager{data: newState()}
+}
+
+// Ping always succeeds; it lets the manager serve as the readiness check.
+func (m *TxManager) Ping(ctx context.Context) error {
+	return nil
+}
+
+// WithTx ignores opts: transactions never overlap, so every one is
+// serializable and none can conflict.
+func (m *TxManager) WithTx(ctx context.Context, fn func(ctx context.Context, t domain.Tx) error, opts ...domain.TxOption) (err error) {
+	ctx, span := tracing.StartTx(ctx, "memory", 0)
+	defer func() { tracing.End(span, err) }()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := *m.data
+	tx := &Tx{data: &snapshot, copied: map[any]bool{}}
+
+	if err := fn(ctx, tx); err != nil {
+		// The committed state was never touched, so dropping tx is the
+		// rollback.
+		return err
+	}
+
+	m.data = tx.data
+	return nil
+}
+
+// Tx is a copy-on-write view of the data: every table is cloned the first
+// time the transaction writes to it, so the committed state stays intact
+// until WithTx swaps it in.
+type Tx struct {
+	data   *state
+	copied map[any]bool
+}
+
+func (t *Tx) Exec(ctx context.Context, sql string, arguments ...any) (int64, error) {
+	return 0, errNoSQL
+}
+
+func (t *Tx) Query(ctx context.Context, sql string, args ...any) (domain.Rows, error) {
+	return nil, errNoSQL
+}
+
+func (t *Tx) QueryRow(ctx context.Context, sql string, args ...any) domain.Row {
+	return errRow{err: errNoSQL}
+}
+
+type errRow struct {
+	err error
+}
+
+func (r errRow) Scan(dest ...any) error {
+	return r.err
+}
+
+// writable returns the transaction's own copy of the table behind m.
+func writable[K comparable, V any](t *Tx, m *map[K]V) map[K]V {
+	if !t.copied[m] {
+		*m = maps.Clone(*m)
+		t.copied[m] = true
+	}
+	return *m
+}
+
+func unwrap(ttx domain.Tx) (*Tx, error) {
+	t, ok := ttx.(*Tx)
+	if !ok {
+		return nil, errForeignTx
+	}
+	return t, nil
+}
+
+var _ domain.TxManager = (*TxManager)(nil)
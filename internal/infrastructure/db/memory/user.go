@@ -0,0 +1,178 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	domain "github.com/user/reviewer-svc/internal/domain"
+	domainuser "github.com/user/reviewer-svc/internal/domain/user"
+	userreassign "github.com/user/reviewer-svc/internal/domain/userreassign"
+)
+
+type UserRepo struct{}
+
+func NewUserRepo() *UserRepo {
+	return &UserRepo{}
+}
+
+func (r *UserRepo) Create(ctx context.Context, ttx domain.Tx, u *domainuser.User) error {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return err
+	}
+	if _, ok := tx.data.users[u.ID]; ok {
+		return domain.ErrAlreadyExists
+	}
+	if _, ok := tx.data.teams[u.TeamID]; !ok {
+		return domain.ErrConstraintViolation
+	}
+	writable(tx, &tx.data.users)[u.ID] = *u
+	return nil
+}
+
+// Upsert keeps the stored max_open_reviews and created_at of an existing
+// user, like the SQL backend's ON CONFLICT clause.
+func (r *UserRepo) Upsert(ctx context.Context, ttx domain.Tx, u *domainuser.User) error {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return err
+	}
+	if _, ok := tx.data.teams[u.TeamID]; !ok {
+		return domain.ErrConstraintViolation
+	}
+	stored, ok := tx.data.users[u.ID]
+	if !ok {
+		stored = domainuser.User{ID: u.ID, CreatedAt: u.CreatedAt}
+	}
+	stored.Name = u.Name
+	stored.TeamID = u.TeamID
+	stored.IsActive = u.IsActive
+	writable(tx, &tx.data.users)[u.ID] = stored
+	return nil
+}
+
+func (r *UserRepo) GetByID(ctx context.Context, ttx domain.Tx, id string) (*domainuser.User, error) {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return nil, err
+	}
+	u, ok := tx.data.users[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return &u, nil
+}
+
+func (r *UserRepo) Update(ctx context.Context, ttx domain.Tx, u *domainuser.User) error {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return err
+	}
+	stored, ok := tx.data.users[u.ID]
+	if !ok {
+		return nil
+	}
+	stored.Name = u.Name
+	stored.IsActive = u.IsActive
+	stored.MaxOpenReviews = u.MaxOpenReviews
+	writable(tx, &tx.data.users)[u.ID] = stored
+	return nil
+}
+
+func (r *UserRepo) List(ctx context.Context, ttx domain.Tx, teamID *string, isActive *bool, page domain.PageRequest) (domain.Page[domainuser.User], error) {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return domain.Page[domainuser.User]{}, err
+	}
+	var res []domainuser.User
+	for _, u := range tx.data.users {
+		if teamID != nil && u.TeamID != *teamID {
+			continue
+		}
+		if isActive != nil && u.IsActive != *isActive {
+			continue
+		}
+		res = append(res, u)
+	}
+	return paginate(res, page, false, userCursor), nil
+}
+
+func (r *UserRepo) ListByIDs(ctx context.Context, ttx domain.Tx, ids []string) ([]domainuser.User, error) {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return nil, err
+	}
+	var res []domainuser.User
+	for _, id := range ids {
+		if u, ok := tx.data.users[id]; ok {
+			res = append(res, u)
+		}
+	}
+	return res, nil
+}
+
+// ListActiveByTeamExcept returns active team members that are not on an
+// absence covering at.
+func (r *UserRepo) ListActiveByTeamExcept(ctx context.Context, ttx domain.Tx, teamID string, exclude []string, at time.Time) ([]domainuser.User, error) {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return nil, err
+	}
+
+	absent := make(map[string]bool)
+	for _, a := range tx.data.absences {
+		if a.ActiveAt(at) {
+			absent[a.UserID] = true
+		}
+	}
+
+	var res []domainuser.User
+	for _, u := range tx.data.users {
+		if u.TeamID != teamID || !u.IsActive || absent[u.ID] || slices.Contains(exclude, u.ID) {
+			continue
+		}
+		res = append(res, u)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return cursorLess(userCursor(res[i]), userCursor(res[j]))
+	})
+	return res, nil
+}
+
+// ListActiveByIDs returns those of ids that are active users and not on an
+// absence covering at.
+func (r *UserRepo) ListActiveByIDs(ctx context.Context, ttx domain.Tx, ids []string, at time.Time) ([]domainuser.User, error) {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return nil, err
+	}
+
+	absent := make(map[string]bool)
+	for _, a := range tx.data.absences {
+		if a.ActiveAt(at) {
+			absent[a.UserID] = true
+		}
+	}
+
+	var res []domainuser.User
+	for _, u := range tx.data.users {
+		if !u.IsActive || absent[u.ID] || !slices.Contains(ids, u.ID) {
+			continue
+		}
+		res = append(res, u)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return cursorLess(userCursor(res[i]), userCursor(res[j]))
+	})
+	return res, nil
+}
+
+func userCursor(u domainuser.User) domain.Cursor {
+	return domain.Cursor{CreatedAt: u.CreatedAt, ID: u.ID}
+}
+
+var _ domainuser.UserRepository = (*UserRepo)(nil)
+var _ domainuser.BulkUserRepository = (*UserRepo)(nil)
+var _ userreassign.ReassignmentUserRepository = (*UserRepo)(nil)
@@ -0,0 +1,224 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"sort"
+	"time"
+
+	domain "github.com/user/reviewer-svc/internal/domain"
+	domainwebhook "github.com/user/reviewer-svc/internal/domain/webhook"
+)
+
+type outboxEntry struct {
+	ID         int64
+	Type       domainwebhook.EventType
+	Payload    []byte
+	OccurredAt time.Time
+}
+
+type WebhookRepo struct{}
+
+func NewWebhookRepo() *WebhookRepo {
+	return &WebhookRepo{}
+}
+
+func (r *WebhookRepo) Enqueue(ctx context.Context, ttx domain.Tx, e domainwebhook.Event) error {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(e.Payload)
+	if err != nil {
+		return err
+	}
+
+	tx.data.lastOut++
+	entry := outboxEntry{ID: tx.data.lastOut, Type: e.Type, Payload: payload, OccurredAt: e.OccurredAt}
+	writable(tx, &tx.data.outbox)[entry.ID] = entry
+
+	for _, s := range tx.data.subs {
+		if !s.IsActive || !slices.Contains(s.Events, e.Type) {
+			continue
+		}
+		tx.data.lastDeliv++
+		writable(tx, &tx.data.delivery)[tx.data.lastDeliv] = domainwebhook.Delivery{
+			ID:             tx.data.lastDeliv,
+			SubscriptionID: s.ID,
+			EventID:        entry.ID,
+			Status:         domainwebhook.DeliveryPending,
+			NextAttemptAt:  e.OccurredAt,
+		}
+	}
+	return nil
+}
+
+func (r *WebhookRepo) CreateSubscription(ctx context.Context, ttx domain.Tx, s *domainwebhook.Subscription) error {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return err
+	}
+	if _, ok := tx.data.subs[s.ID]; ok {
+		return domain.ErrAlreadyExists
+	}
+	stored := *s
+	stored.Events = slices.Clone(s.Events)
+	writable(tx, &tx.data.subs)[s.ID] = stored
+	return nil
+}
+
+func (r *WebhookRepo) ListSubscriptions(ctx context.Context, ttx domain.Tx) ([]domainwebhook.Subscription, error) {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return nil, err
+	}
+	var res []domainwebhook.Subscription
+	for _, s := range tx.data.subs {
+		s.Events = slices.Clone(s.Events)
+		res = append(res, s)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return cursorLess(domain.Cursor{CreatedAt: res[i].CreatedAt, ID: res[i].ID}, domain.Cursor{CreatedAt: res[j].CreatedAt, ID: res[j].ID})
+	})
+	return res, nil
+}
+
+// DeleteSubscription drops the subscription's deliveries as well, like the
+// ON DELETE CASCADE of the SQL schema.
+func (r *WebhookRepo) DeleteSubscription(ctx context.Context, ttx domain.Tx, id string) error {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return err
+	}
+	if _, ok := tx.data.subs[id]; !ok {
+		return domain.ErrNotFound
+	}
+	delete(writable(tx, &tx.data.subs), id)
+	for dID, d := range tx.data.delivery {
+		if d.SubscriptionID == id {
+			delete(writable(tx, &tx.data.delivery), dID)
+		}
+	}
+	return nil
+}
+
+func (r *WebhookRepo) ListDeadLetters(ctx context.Context, ttx domain.Tx) ([]domainwebhook.Delivery, error) {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return nil, err
+	}
+	var res []domainwebhook.Delivery
+	for _, d := range tx.data.delivery {
+		if d.Status != domainwebhook.DeliveryDead {
+			continue
+		}
+		d = joinDelivery(tx.data, d)
+		d.Secret = ""
+		res = append(res, d)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ID < res[j].ID })
+	return res, nil
+}
+
+func (r *WebhookRepo) RetryDelivery(ctx context.Context, ttx domain.Tx, id int64, at time.Time) error {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return err
+	}
+	d, ok := tx.data.delivery[id]
+	if !ok || d.Status != domainwebhook.DeliveryDead {
+		return domain.ErrNotFound
+	}
+	d.Status = domainwebhook.DeliveryPending
+	d.Attempts = 0
+	d.NextAttemptAt = at
+	writable(tx, &tx.data.delivery)[id] = d
+	return nil
+}
+
+func (r *WebhookRepo) ClaimDue(ctx context.Context, ttx domain.Tx, now time.Time, leaseUntil time.Time, limit int) ([]domainwebhook.Delivery, error) {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return nil, err
+	}
+
+	var due []domainwebhook.Delivery
+	for _, d := range tx.data.delivery {
+		if d.Status == domainwebhook.DeliveryPending && !d.NextAttemptAt.After(now) {
+			due = append(due, d)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].NextAttemptAt.Equal(due[j].NextAttemptAt) {
+			return due[i].NextAttemptAt.Before(due[j].NextAttemptAt)
+		}
+		return due[i].ID < due[j].ID
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	res := make([]domainwebhook.Delivery, 0, len(due))
+	for _, d := range due {
+		d.NextAttemptAt = leaseUntil
+		writable(tx, &tx.data.delivery)[d.ID] = d
+		res = append(res, joinDelivery(tx.data, d))
+	}
+	return res, nil
+}
+
+func (r *WebhookRepo) MarkDelivered(ctx context.Context, ttx domain.Tx, id int64, at time.Time) error {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return err
+	}
+	d, ok := tx.data.delivery[id]
+	if !ok {
+		return nil
+	}
+	d.Status = domainwebhook.DeliveryDelivered
+	d.Attempts++
+	d.DeliveredAt = &at
+	d.LastError = nil
+	writable(tx, &tx.data.delivery)[id] = d
+	return nil
+}
+
+func (r *WebhookRepo) MarkFailed(ctx context.Context, ttx domain.Tx, id int64, attempts int, nextAttemptAt time.Time, lastErr string, dead bool) error {
+	tx, err := unwrap(ttx)
+	if err != nil {
+		return err
+	}
+	d, ok := tx.data.delivery[id]
+	if !ok {
+		return nil
+	}
+	d.Status = domainwebhook.DeliveryPending
+	if dead {
+		d.Status = domainwebhook.DeliveryDead
+	}
+	d.Attempts = attempts
+	d.NextAttemptAt = nextAttemptAt
+	d.LastError = &lastErr
+	writable(tx, &tx.data.delivery)[id] = d
+	return nil
+}
+
+// joinDelivery fills in the subscription and event fields of d.
+func joinDelivery(s *state, d domainwebhook.Delivery) domainwebhook.Delivery {
+	if sub, ok := s.subs[d.SubscriptionID]; ok {
+		d.URL = sub.URL
+		d.Secret = sub.Secret
+	}
+	if e, ok := s.outbox[d.EventID]; ok {
+		d.EventType = e.Type
+		d.Payload = e.Payload
+		d.OccurredAt = e.OccurredAt
+	}
+	return d
+}
+
+var _ domainwebhook.Outbox = (*WebhookRepo)(nil)
+var _ domainwebhook.Repository = (*WebhookRepo)(nil)
+var _ domainwebhook.DeliveryRepository = (*WebhookRepo)(nil)
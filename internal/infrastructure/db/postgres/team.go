@@ -15,45 +15,149 @@ func NewTeamRepo() *TeamRepo {
 
 func (r *TeamRepo) Create(ctx context.Context, ttx domain.Tx, t *domainteam.Team) error {
 	_, err := ttx.Exec(ctx,
-		"INSERT INTO teams (id, name, created_at) VALUES ($1, $2, $3)",
-		t.ID, t.Name, t.CreatedAt,
+		"INSERT INTO teams (id, name, required_reviewers, saturation_policy, created_at) VALUES ($1, $2, $3, $4, $5)",
+		t.ID, t.Name, t.RequiredReviewers, string(t.SaturationPolicy), t.CreatedAt,
 	)
 	return translateError(err)
 }
 
 func (r *TeamRepo) GetByID(ctx context.Context, ttx domain.Tx, id string) (*domainteam.Team, error) {
 	row := ttx.QueryRow(ctx,
-		"SELECT id, name, created_at FROM teams WHERE id = $1",
+		"SELECT id, name, required_reviewers, saturation_policy, created_at FROM teams WHERE id = $1",
 		id,
 	)
 	var t domainteam.Team
-	if err := row.Scan(&t.ID, &t.Name, &t.CreatedAt); err != nil {
+	if err := row.Scan(&t.ID, &t.Name, &t.RequiredReviewers, &t.SaturationPolicy, &t.CreatedAt); err != nil {
 		return nil, translateError(err)
 	}
-	return &t, nil
+	res := []domainteam.Team{t}
+	if err := r.loadFallbacks(ctx, ttx, res); err != nil {
+		return nil, err
+	}
+	return &res[0], nil
 }
 
-func (r *TeamRepo) List(ctx context.Context, ttx domain.Tx) ([]domainteam.Team, error) {
-	rows, err := ttx.Query(ctx,
-		"SELECT id, name, created_at FROM teams ORDER BY created_at",
+func (r *TeamRepo) Update(ctx context.Context, ttx domain.Tx, t *domainteam.Team) error {
+	n, err := ttx.Exec(ctx,
+		"UPDATE teams SET name = $1, required_reviewers = $2, saturation_policy = $3 WHERE id = $4",
+		t.Name, t.RequiredReviewers, string(t.SaturationPolicy), t.ID,
 	)
 	if err != nil {
+		return translateError(err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *TeamRepo) GetByName(ctx context.Context, ttx domain.Tx, name string) (*domainteam.Team, error) {
+	row := ttx.QueryRow(ctx,
+		"SELECT id, name, required_reviewers, saturation_policy, created_at FROM teams WHERE name = $1",
+		name,
+	)
+	var t domainteam.Team
+	if err := row.Scan(&t.ID, &t.Name, &t.RequiredReviewers, &t.SaturationPolicy, &t.CreatedAt); err != nil {
 		return nil, translateError(err)
 	}
+	res := []domainteam.Team{t}
+	if err := r.loadFallbacks(ctx, ttx, res); err != nil {
+		return nil, err
+	}
+	return &res[0], nil
+}
+
+func (r *TeamRepo) List(ctx context.Context, ttx domain.Tx, page domain.PageRequest) (domain.Page[domainteam.Team], error) {
+	query := "SELECT id, name, required_reviewers, saturation_policy, created_at FROM teams"
+	var args []any
+	if page.Cursor != nil {
+		var cond string
+		cond, args = keysetCondition(page.Cursor, "", false, args)
+		query += " WHERE " + cond
+	}
+	order, args := keysetOrder(page, "", false, args)
+	query += order
+
+	rows, err := ttx.Query(ctx, query, args...)
+	if err != nil {
+		return domain.Page[domainteam.Team]{}, translateError(err)
+	}
 	defer rows.Close()
 
 	var res []domainteam.Team
 	for rows.Next() {
 		var t domainteam.Team
-		if err := rows.Scan(&t.ID, &t.Name, &t.CreatedAt); err != nil {
-			return nil, err
+		if err := rows.Scan(&t.ID, &t.Name, &t.RequiredReviewers, &t.SaturationPolicy, &t.CreatedAt); err != nil {
+			return domain.Page[domainteam.Team]{}, err
 		}
 		res = append(res, t)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, err
+		return domain.Page[domainteam.Team]{}, err
+	}
+	if err := r.loadFallbacks(ctx, ttx, res); err != nil {
+		return domain.Page[domainteam.Team]{}, err
+	}
+	return domain.NewPage(res, page, teamCursor), nil
+}
+
+func (r *TeamRepo) SetFallbacks(ctx context.Context, ttx domain.Tx, teamID string, fallbackIDs []string) error {
+	if _, err := ttx.Exec(ctx, "DELETE FROM team_fallbacks WHERE team_id = $1", teamID); err != nil {
+		return translateError(err)
+	}
+	for i, id := range fallbackIDs {
+		_, err := ttx.Exec(ctx,
+			"INSERT INTO team_fallbacks (team_id, position, fallback_team_id) VALUES ($1, $2, $3)",
+			teamID, i+1, id,
+		)
+		if err != nil {
+			return translateError(err)
+		}
+	}
+	return nil
+}
+
+// loadFallbacks fills in the fallback teams of every team in teams.
+func (r *TeamRepo) loadFallbacks(ctx context.Context, ttx domain.Tx, teams []domainteam.Team) error {
+	if len(teams) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(teams))
+	for _, t := range teams {
+		ids = append(ids, t.ID)
+	}
+
+	query, args := buildStringInQuery(
+		"SELECT f.team_id, t.id, t.name FROM team_fallbacks f JOIN teams t ON t.id = f.fallback_team_id WHERE f.team_id IN (",
+		") ORDER BY f.team_id, f.position",
+		ids,
+	)
+	rows, err := ttx.Query(ctx, query, args...)
+	if err != nil {
+		return translateError(err)
 	}
-	return res, nil
+	defer rows.Close()
+
+	byTeam := make(map[string][]domainteam.TeamRef)
+	for rows.Next() {
+		var teamID string
+		var ref domainteam.TeamRef
+		if err := rows.Scan(&teamID, &ref.ID, &ref.Name); err != nil {
+			return err
+		}
+		byTeam[teamID] = append(byTeam[teamID], ref)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for i := range teams {
+		teams[i].FallbackTeams = byTeam[teams[i].ID]
+	}
+	return nil
+}
+
+func teamCursor(t domainteam.Team) domain.Cursor {
+	return domain.Cursor{CreatedAt: t.CreatedAt, ID: t.ID}
 }
 
 var _ domainteam.Repository = (*TeamRepo)(nil)
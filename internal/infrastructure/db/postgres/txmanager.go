@@ -2,29 +2,94 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand/v2"
+	"sync/atomic"
+	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	domain "github.com/user/reviewer-svc/internal/domain"
+	"github.com/user/reviewer-svc/internal/infrastructure/tracing"
 )
 
+// dbSystem is the OpenTelemetry name of the database.
+const dbSystem = "postgresql"
+
+const (
+	retryBaseDelay = 10 * time.Millisecond
+	retryMaxDelay  = 500 * time.Millisecond
+
+	// replicaBeginTimeout keeps an unreachable replica from eating the
+	// request deadline before the primary is tried.
+	replicaBeginTimeout = time.Second
+	// replicaCooldown is how long reads stay on the primary after the
+	// replica failed to start a transaction.
+	replicaCooldown = 5 * time.Second
+)
 
 type TxManager struct {
-	pool *pgxpool.Pool
+	pool    *pgxpool.Pool
+	replica *pgxpool.Pool
+
+	// replicaDownUntil is a UnixNano deadline before which the replica is
+	// not tried again.
+	replicaDownUntil atomic.Int64
 }
 
 func NewTxManager(pool *pgxpool.Pool) *TxManager {
 	return &TxManager{pool: pool}
 }
 
+// NewReplicatedTxManager sends transactions that allow it to replica and
+// everything else to primary.
+func NewReplicatedTxManager(primary, replica *pgxpool.Pool) *TxManager {
+	return &TxManager{pool: primary, replica: replica}
+}
+
 func (m *TxManager) Pool() *pgxpool.Pool {
 	return m.pool
 }
 
-func (m *TxManager) WithTx(ctx context.Context, fn func(ctx context.Context, t domain.Tx) error) error {
-	pgxTx, err := m.pool.BeginTx(ctx, pgx.TxOptions{})
+// Replica returns the replica pool, or nil when none is configured.
+func (m *TxManager) Replica() *pgxpool.Pool {
+	return m.replica
+}
+
+// WithTx runs fn in a transaction and reruns it from scratch when Postgres
+// aborts it with a serialization failure or a deadlock, up to the
+// configured number of retries.
+func (m *TxManager) WithTx(ctx context.Context, fn func(ctx context.Context, t domain.Tx) error, opts ...domain.TxOption) error {
+	o := domain.BuildTxOptions(opts...)
+	txOpts := pgx.TxOptions{IsoLevel: isoLevel(o.Isolation)}
+	if o.ReadOnly {
+		txOpts.AccessMode = pgx.ReadOnly
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := m.runTx(ctx, txOpts, o.AllowReplica, attempt, fn)
+		if err == nil || attempt >= o.MaxRetries || !isRetryable(err) {
+			return err
+		}
+
+		timer := time.NewTimer(retryDelay(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+func (m *TxManager) runTx(ctx context.Context, txOpts pgx.TxOptions, allowReplica bool, attempt int, fn func(ctx context.Context, t domain.Tx) error) (err error) {
+	ctx, span := tracing.StartTx(ctx, dbSystem, attempt)
+	defer func() { tracing.End(span, err) }()
+
+	pgxTx, err := m.begin(ctx, txOpts, allowReplica)
 	if err != nil {
 		return err
 	}
@@ -41,25 +106,80 @@ func (m *TxManager) WithTx(ctx context.Context, fn func(ctx context.Context, t d
 	return pgxTx.Commit(ctx)
 }
 
+// begin starts the transaction on the replica when allowed and available,
+// falling back to the primary if the replica cannot be reached.
+func (m *TxManager) begin(ctx context.Context, txOpts pgx.TxOptions, allowReplica bool) (pgx.Tx, error) {
+	if allowReplica && m.replica != nil && time.Now().UnixNano() >= m.replicaDownUntil.Load() {
+		beginCtx, cancel := context.WithTimeout(ctx, replicaBeginTimeout)
+		tx, err := m.replica.BeginTx(beginCtx, txOpts)
+		cancel()
+		if err == nil {
+			return tx, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		m.replicaDownUntil.Store(time.Now().Add(replicaCooldown).UnixNano())
+	}
+	return m.pool.BeginTx(ctx, txOpts)
+}
+
+func isoLevel(level domain.IsolationLevel) pgx.TxIsoLevel {
+	switch level {
+	case domain.IsolationReadCommitted:
+		return pgx.ReadCommitted
+	case domain.IsolationRepeatableRead:
+		return pgx.RepeatableRead
+	case domain.IsolationSerializable:
+		return pgx.Serializable
+	}
+	return ""
+}
+
+// isRetryable reports whether err is serialization_failure or
+// deadlock_detected, after which the whole transaction may simply be rerun.
+func isRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == "40001" || pgErr.Code == "40P01"
+}
+
+// retryDelay is an exponential backoff with full jitter, so transactions
+// that collided once do not collide again on the next attempt.
+func retryDelay(attempt int) time.Duration {
+	d := retryBaseDelay << attempt
+	if d <= 0 || d > retryMaxDelay {
+		d = retryMaxDelay
+	}
+	return rand.N(d) + 1
+}
+
 type txWrapper struct {
 	pgx.Tx
 }
 
 func (t *txWrapper) Exec(ctx context.Context, sql string, args ...any) (int64, error) {
+	ctx, span := tracing.StartQuery(ctx, dbSystem, sql)
 	cmd, err := t.Tx.Exec(ctx, sql, args...)
+	tracing.EndQuery(span, cmd.RowsAffected(), err)
 	return cmd.RowsAffected(), err
 }
 
 func (t *txWrapper) Query(ctx context.Context, sql string, args ...any) (domain.Rows, error) {
+	ctx, span := tracing.StartQuery(ctx, dbSystem, sql)
 	rows, err := t.Tx.Query(ctx, sql, args...)
 	if err != nil {
+		tracing.EndQuery(span, 0, err)
 		return nil, err
 	}
-	return rows, nil
+	return tracing.WrapRows(rows, span), nil
 }
 
 func (t *txWrapper) QueryRow(ctx context.Context, sql string, args ...any) domain.Row {
-	return t.Tx.QueryRow(ctx, sql, args...)
+	ctx, span := tracing.StartQuery(ctx, dbSystem, sql)
+	return tracing.WrapRow(t.Tx.QueryRow(ctx, sql, args...), span)
 }
 
 var _ domain.TxManager = (*TxManager)(nil)
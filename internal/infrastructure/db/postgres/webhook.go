@@ -0,0 +1,198 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	domain "github.com/user/reviewer-svc/internal/domain"
+	domainwebhook "github.com/user/reviewer-svc/internal/domain/webhook"
+)
+
+type WebhookRepo struct{}
+
+func NewWebhookRepo() *WebhookRepo {
+	return &WebhookRepo{}
+}
+
+func (r *WebhookRepo) Enqueue(ctx context.Context, ttx domain.Tx, e domainwebhook.Event) error {
+	payload, err := json.Marshal(e.Payload)
+	if err != nil {
+		return err
+	}
+
+	var outboxID int64
+	row := ttx.QueryRow(ctx,
+		"INSERT INTO webhook_outbox (event_type, payload, occurred_at) VALUES ($1, $2, $3) RETURNING id",
+		string(e.Type), string(payload), e.OccurredAt,
+	)
+	if err := row.Scan(&outboxID); err != nil {
+		return translateError(err)
+	}
+
+	_, err = ttx.Exec(ctx,
+		"INSERT INTO webhook_deliveries (outbox_id, subscription_id, status, attempts, next_attempt_at)"+
+			" SELECT $1, id, 'PENDING', 0, $2 FROM webhook_subscriptions WHERE is_active AND $3 = ANY(events)",
+		outboxID, e.OccurredAt, string(e.Type),
+	)
+	return translateError(err)
+}
+
+func (r *WebhookRepo) CreateSubscription(ctx context.Context, ttx domain.Tx, s *domainwebhook.Subscription) error {
+	_, err := ttx.Exec(ctx,
+		"INSERT INTO webhook_subscriptions (id, url, secret, events, is_active, created_at) VALUES ($1, $2, $3, $4, $5, $6)",
+		s.ID, s.URL, s.Secret, eventsToStrings(s.Events), s.IsActive, s.CreatedAt,
+	)
+	return translateError(err)
+}
+
+func (r *WebhookRepo) ListSubscriptions(ctx context.Context, ttx domain.Tx) ([]domainwebhook.Subscription, error) {
+	rows, err := ttx.Query(ctx,
+		"SELECT id, url, secret, events, is_active, created_at FROM webhook_subscriptions ORDER BY created_at",
+	)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	defer rows.Close()
+
+	var res []domainwebhook.Subscription
+	for rows.Next() {
+		var s domainwebhook.Subscription
+		var events []string
+		if err := rows.Scan(&s.ID, &s.URL, &s.Secret, &events, &s.IsActive, &s.CreatedAt); err != nil {
+			return nil, err
+		}
+		s.Events = eventsFromStrings(events)
+		res = append(res, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *WebhookRepo) DeleteSubscription(ctx context.Context, ttx domain.Tx, id string) error {
+	n, err := ttx.Exec(ctx, "DELETE FROM webhook_subscriptions WHERE id = $1", id)
+	if err != nil {
+		return translateError(err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *WebhookRepo) ListDeadLetters(ctx context.Context, ttx domain.Tx) ([]domainwebhook.Delivery, error) {
+	rows, err := ttx.Query(ctx,
+		"SELECT id, subscription_id, url, outbox_id, event_type, payload, occurred_at, attempts, next_attempt_at, last_error"+
+			" FROM webhook_dead_letters ORDER BY id",
+	)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	defer rows.Close()
+
+	var res []domainwebhook.Delivery
+	for rows.Next() {
+		var d domainwebhook.Delivery
+		var eventType string
+		if err := rows.Scan(&d.ID, &d.SubscriptionID, &d.URL, &d.EventID, &eventType, &d.Payload, &d.OccurredAt, &d.Attempts, &d.NextAttemptAt, &d.LastError); err != nil {
+			return nil, err
+		}
+		d.EventType = domainwebhook.EventType(eventType)
+		d.Status = domainwebhook.DeliveryDead
+		res = append(res, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *WebhookRepo) RetryDelivery(ctx context.Context, ttx domain.Tx, id int64, at time.Time) error {
+	n, err := ttx.Exec(ctx,
+		"UPDATE webhook_deliveries SET status = 'PENDING', attempts = 0, next_attempt_at = $1 WHERE id = $2 AND status = 'DEAD'",
+		at, id,
+	)
+	if err != nil {
+		return translateError(err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *WebhookRepo) ClaimDue(ctx context.Context, ttx domain.Tx, now time.Time, leaseUntil time.Time, limit int) ([]domainwebhook.Delivery, error) {
+	rows, err := ttx.Query(ctx,
+		"WITH due AS ("+
+			" SELECT id FROM webhook_deliveries WHERE status = 'PENDING' AND next_attempt_at <= $1"+
+			" ORDER BY next_attempt_at, id LIMIT $3 FOR UPDATE SKIP LOCKED)"+
+			" UPDATE webhook_deliveries d SET next_attempt_at = $2"+
+			" FROM due, webhook_outbox o, webhook_subscriptions s"+
+			" WHERE d.id = due.id AND o.id = d.outbox_id AND s.id = d.subscription_id"+
+			" RETURNING d.id, d.subscription_id, s.url, s.secret, o.id, o.event_type, o.payload, o.occurred_at, d.attempts",
+		now, leaseUntil, limit,
+	)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	defer rows.Close()
+
+	var res []domainwebhook.Delivery
+	for rows.Next() {
+		var d domainwebhook.Delivery
+		var eventType string
+		if err := rows.Scan(&d.ID, &d.SubscriptionID, &d.URL, &d.Secret, &d.EventID, &eventType, &d.Payload, &d.OccurredAt, &d.Attempts); err != nil {
+			return nil, err
+		}
+		d.EventType = domainwebhook.EventType(eventType)
+		d.Status = domainwebhook.DeliveryPending
+		d.NextAttemptAt = leaseUntil
+		res = append(res, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *WebhookRepo) MarkDelivered(ctx context.Context, ttx domain.Tx, id int64, at time.Time) error {
+	_, err := ttx.Exec(ctx,
+		"UPDATE webhook_deliveries SET status = 'DELIVERED', attempts = attempts + 1, delivered_at = $1, last_error = NULL WHERE id = $2",
+		at, id,
+	)
+	return translateError(err)
+}
+
+func (r *WebhookRepo) MarkFailed(ctx context.Context, ttx domain.Tx, id int64, attempts int, nextAttemptAt time.Time, lastErr string, dead bool) error {
+	status := string(domainwebhook.DeliveryPending)
+	if dead {
+		status = string(domainwebhook.DeliveryDead)
+	}
+	_, err := ttx.Exec(ctx,
+		"UPDATE webhook_deliveries SET status = $1, attempts = $2, next_attempt_at = $3, last_error = $4 WHERE id = $5",
+		status, attempts, nextAttemptAt, lastErr, id,
+	)
+	return translateError(err)
+}
+
+func eventsToStrings(events []domainwebhook.EventType) []string {
+	res := make([]string, 0, len(events))
+	for _, e := range events {
+		res = append(res, string(e))
+	}
+	return res
+}
+
+func eventsFromStrings(events []string) []domainwebhook.EventType {
+	res := make([]domainwebhook.EventType, 0, len(events))
+	for _, e := range events {
+		res = append(res, domainwebhook.EventType(e))
+	}
+	return res
+}
+
+var _ domainwebhook.Outbox = (*WebhookRepo)(nil)
+var _ domainwebhook.Repository = (*WebhookRepo)(nil)
+var _ domainwebhook.DeliveryRepository = (*WebhookRepo)(nil)
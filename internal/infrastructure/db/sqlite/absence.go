@@ -0,0 +1,111 @@
+package sqlite
+
+import (
+	"context"
+	"time"
+
+	domain "github.com/user/reviewer-svc/internal/domain"
+	domainuser "github.com/user/reviewer-svc/internal/domain/user"
+)
+
+const absenceColumns = "id, user_id, starts_at, ends_at, reason, reassign_open_prs, reassigned_at, created_at"
+
+type AbsenceRepo struct{}
+
+func NewAbsenceRepo() *AbsenceRepo {
+	return &AbsenceRepo{}
+}
+
+func (r *AbsenceRepo) Create(ctx context.Context, ttx domain.Tx, a *domainuser.Absence) error {
+	_, err := ttx.Exec(ctx,
+		"INSERT INTO user_absences ("+absenceColumns+") VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
+		a.ID, a.UserID, a.StartsAt, a.EndsAt, a.Reason, a.ReassignOpenPRs, a.ReassignedAt, a.CreatedAt,
+	)
+	return translateError(err)
+}
+
+// GetByID ignores forUpdate: transactions share one connection and never
+// overlap.
+func (r *AbsenceRepo) GetByID(ctx context.Context, ttx domain.Tx, id string, forUpdate bool) (*domainuser.Absence, error) {
+	query := "SELECT " + absenceColumns + " FROM user_absences WHERE id = $1"
+	a, err := scanAbsence(ttx.QueryRow(ctx, query, id))
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return a, nil
+}
+
+func (r *AbsenceRepo) Update(ctx context.Context, ttx domain.Tx, a *domainuser.Absence) error {
+	n, err := ttx.Exec(ctx,
+		`UPDATE user_absences
+		SET starts_at = $1, ends_at = $2, reason = $3, reassign_open_prs = $4, reassigned_at = $5
+		WHERE id = $6`,
+		a.StartsAt, a.EndsAt, a.Reason, a.ReassignOpenPRs, a.ReassignedAt, a.ID,
+	)
+	if err != nil {
+		return translateError(err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *AbsenceRepo) Delete(ctx context.Context, ttx domain.Tx, id string) error {
+	n, err := ttx.Exec(ctx, "DELETE FROM user_absences WHERE id = $1", id)
+	if err != nil {
+		return translateError(err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *AbsenceRepo) ListByUser(ctx context.Context, ttx domain.Tx, userID string) ([]domainuser.Absence, error) {
+	return r.query(ctx, ttx,
+		"SELECT "+absenceColumns+" FROM user_absences WHERE user_id = $1 ORDER BY starts_at, id",
+		userID,
+	)
+}
+
+func (r *AbsenceRepo) ListReassignmentDue(ctx context.Context, ttx domain.Tx, now time.Time, limit int) ([]domainuser.Absence, error) {
+	return r.query(ctx, ttx,
+		`SELECT `+absenceColumns+` FROM user_absences
+		WHERE reassign_open_prs AND reassigned_at IS NULL AND starts_at <= $1 AND ends_at > $1
+		ORDER BY starts_at, id
+		LIMIT $2`,
+		now, limit,
+	)
+}
+
+func (r *AbsenceRepo) query(ctx context.Context, ttx domain.Tx, query string, args ...any) ([]domainuser.Absence, error) {
+	rows, err := ttx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	defer rows.Close()
+
+	var res []domainuser.Absence
+	for rows.Next() {
+		a, err := scanAbsence(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, *a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func scanAbsence(row domain.Row) (*domainuser.Absence, error) {
+	var a domainuser.Absence
+	if err := row.Scan(&a.ID, &a.UserID, &a.StartsAt, &a.EndsAt, &a.Reason, &a.ReassignOpenPRs, &a.ReassignedAt, &a.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+var _ domainuser.AbsenceRepository = (*AbsenceRepo)(nil)
@@ -0,0 +1,86 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	domain "github.com/user/reviewer-svc/internal/domain"
+	domainapikey "github.com/user/reviewer-svc/internal/domain/apikey"
+)
+
+const apiKeyColumns = "id, name, prefix, secret_hash, scopes, created_at, revoked_at"
+
+type APIKeyRepo struct{}
+
+func NewAPIKeyRepo() *APIKeyRepo {
+	return &APIKeyRepo{}
+}
+
+func (r *APIKeyRepo) Create(ctx context.Context, ttx domain.Tx, k *domainapikey.APIKey) error {
+	scopes, err := json.Marshal(k.Scopes)
+	if err != nil {
+		return err
+	}
+	_, err = ttx.Exec(ctx,
+		"INSERT INTO api_keys ("+apiKeyColumns+") VALUES ($1, $2, $3, $4, $5, $6, $7)",
+		k.ID, k.Name, k.Prefix, k.Hash, string(scopes), k.CreatedAt, k.RevokedAt,
+	)
+	return translateError(err)
+}
+
+func (r *APIKeyRepo) GetByID(ctx context.Context, ttx domain.Tx, id string) (*domainapikey.APIKey, error) {
+	row := ttx.QueryRow(ctx, "SELECT "+apiKeyColumns+" FROM api_keys WHERE id = $1", id)
+	return scanAPIKey(row)
+}
+
+func (r *APIKeyRepo) GetByHash(ctx context.Context, ttx domain.Tx, hash string) (*domainapikey.APIKey, error) {
+	row := ttx.QueryRow(ctx, "SELECT "+apiKeyColumns+" FROM api_keys WHERE secret_hash = $1", hash)
+	return scanAPIKey(row)
+}
+
+func (r *APIKeyRepo) List(ctx context.Context, ttx domain.Tx) ([]domainapikey.APIKey, error) {
+	rows, err := ttx.Query(ctx, "SELECT "+apiKeyColumns+" FROM api_keys ORDER BY created_at, id")
+	if err != nil {
+		return nil, translateError(err)
+	}
+	defer rows.Close()
+
+	var res []domainapikey.APIKey
+	for rows.Next() {
+		k, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, *k)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *APIKeyRepo) Revoke(ctx context.Context, ttx domain.Tx, id string, at time.Time) error {
+	n, err := ttx.Exec(ctx, "UPDATE api_keys SET revoked_at = $2 WHERE id = $1", id, at)
+	if err != nil {
+		return translateError(err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func scanAPIKey(row domain.Row) (*domainapikey.APIKey, error) {
+	var k domainapikey.APIKey
+	var scopes string
+	if err := row.Scan(&k.ID, &k.Name, &k.Prefix, &k.Hash, &scopes, &k.CreatedAt, &k.RevokedAt); err != nil {
+		return nil, translateError(err)
+	}
+	if err := json.Unmarshal([]byte(scopes), &k.Scopes); err != nil {
+		return nil, err
+	}
+	return &k, nil
+}
+
+var _ domainapikey.Repository = (*APIKeyRepo)(nil)
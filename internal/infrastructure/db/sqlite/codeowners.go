@@ -0,0 +1,37 @@
+package sqlite
+
+import (
+	"context"
+
+	domain "github.com/user/reviewer-svc/internal/domain"
+	domaincodeowners "github.com/user/reviewer-svc/internal/domain/codeowners"
+)
+
+type CodeownersRepo struct{}
+
+func NewCodeownersRepo() *CodeownersRepo {
+	return &CodeownersRepo{}
+}
+
+func (r *CodeownersRepo) Get(ctx context.Context, ttx domain.Tx, teamID string) (*domaincodeowners.Ruleset, error) {
+	row := ttx.QueryRow(ctx,
+		"SELECT team_id, source, updated_at FROM team_codeowners WHERE team_id = $1",
+		teamID,
+	)
+	var rs domaincodeowners.Ruleset
+	if err := row.Scan(&rs.TeamID, &rs.Source, &rs.UpdatedAt); err != nil {
+		return nil, translateError(err)
+	}
+	return &rs, nil
+}
+
+func (r *CodeownersRepo) Save(ctx context.Context, ttx domain.Tx, rs *domaincodeowners.Ruleset) error {
+	_, err := ttx.Exec(ctx,
+		`INSERT INTO team_codeowners (team_id, source, updated_at) VALUES ($1, $2, $3)
+		ON CONFLICT (team_id) DO UPDATE SET source = EXCLUDED.source, updated_at = EXCLUDED.updated_at`,
+		rs.TeamID, rs.Source, rs.UpdatedAt,
+	)
+	return translateError(err)
+}
+
+var _ domaincodeowners.Repository = (*CodeownersRepo)(nil)
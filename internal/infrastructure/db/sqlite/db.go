@@ -0,0 +1,45 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pressly/goose/v3"
+	_ "modernc.org/sqlite"
+)
+
+// DSNScheme prefixes DB_DSN values that point at a SQLite database file,
+// e.g. sqlite:///var/lib/reviewer-svc/reviewer.db.
+const DSNScheme = "sqlite://"
+
+// Open opens the database named by dsn. All access goes through one
+// connection: SQLite serializes writers anyway, and a single connection keeps
+// transactions from failing with SQLITE_BUSY.
+func Open(dsn string) (*sql.DB, error) {
+	path, ok := strings.CutPrefix(dsn, DSNScheme)
+	if !ok || path == "" {
+		return nil, fmt.Errorf("sqlite: DSN must look like %s<path>", DSNScheme)
+	}
+
+	// Times are written in SQLite's own text format so that they sort
+	// correctly; txWrapper converts them to UTC first.
+	db, err := sql.Open("sqlite", "file:"+path+
+		"?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_time_format=sqlite")
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+	return db, nil
+}
+
+func Migrate(ctx context.Context, db *sql.DB, dir string) error {
+	provider, err := goose.NewProvider(goose.DialectSQLite3, db, os.DirFS(dir))
+	if err != nil {
+		return err
+	}
+	_, err = provider.Up(ctx)
+	return err
+}
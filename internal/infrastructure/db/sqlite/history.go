@@ -0,0 +1,62 @@
+package sqlite
+
+import (
+	"context"
+
+	domain "github.com/user/reviewer-svc/internal/domain"
+	domainpr "github.com/user/reviewer-svc/internal/domain/pr"
+	userreassign "github.com/user/reviewer-svc/internal/domain/userreassign"
+)
+
+type HistoryRepo struct{}
+
+func NewHistoryRepo() *HistoryRepo {
+	return &HistoryRepo{}
+}
+
+func (r *HistoryRepo) Append(ctx context.Context, ttx domain.Tx, events []domainpr.AssignmentEvent) error {
+	for _, e := range events {
+		_, err := ttx.Exec(ctx,
+			"INSERT INTO pr_assignment_events (pr_id, event_type, slot, old_user_id, new_user_id, actor, reason, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
+			e.PRID, string(e.Type), e.Slot, e.OldUserID, e.NewUserID, e.Actor, e.Reason, e.CreatedAt,
+		)
+		if err != nil {
+			return translateError(err)
+		}
+	}
+	return nil
+}
+
+func (r *HistoryRepo) ListByPR(ctx context.Context, ttx domain.Tx, prID string) ([]domainpr.AssignmentEvent, error) {
+	rows, err := ttx.Query(ctx,
+		"SELECT id, pr_id, event_type, slot, old_user_id, new_user_id, actor, reason, created_at FROM pr_assignment_events WHERE pr_id = $1 ORDER BY id",
+		prID,
+	)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	defer rows.Close()
+
+	var res []domainpr.AssignmentEvent
+	for rows.Next() {
+		var e domainpr.AssignmentEvent
+		var eventType string
+		var slot *int16
+		if err := rows.Scan(&e.ID, &e.PRID, &eventType, &slot, &e.OldUserID, &e.NewUserID, &e.Actor, &e.Reason, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		e.Type = domainpr.AssignmentEventType(eventType)
+		if slot != nil {
+			v := int(*slot)
+			e.Slot = &v
+		}
+		res = append(res, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+var _ domainpr.AssignmentEventRepository = (*HistoryRepo)(nil)
+var _ userreassign.ReassignmentEventRepository = (*HistoryRepo)(nil)
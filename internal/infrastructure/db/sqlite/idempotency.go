@@ -0,0 +1,82 @@
+package sqlite
+
+import (
+	"context"
+	"time"
+
+	domain "github.com/user/reviewer-svc/internal/domain"
+	domainidempotency "github.com/user/reviewer-svc/internal/domain/idempotency"
+)
+
+const idempotencyColumns = "scope, idem_key, request_hash, status, content_type, body, created_at, expires_at"
+
+type IdempotencyRepo struct{}
+
+func NewIdempotencyRepo() *IdempotencyRepo {
+	return &IdempotencyRepo{}
+}
+
+func (r *IdempotencyRepo) Create(ctx context.Context, ttx domain.Tx, rec *domainidempotency.Record) error {
+	_, err := ttx.Exec(ctx,
+		"INSERT INTO idempotency_keys ("+idempotencyColumns+") VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
+		rec.Scope, rec.Key, rec.RequestHash, rec.Status, rec.ContentType, rec.Body, rec.CreatedAt, rec.ExpiresAt,
+	)
+	return translateError(err)
+}
+
+// Get ignores forUpdate: transactions share one connection and never
+// overlap.
+func (r *IdempotencyRepo) Get(ctx context.Context, ttx domain.Tx, scope, key string, forUpdate bool) (*domainidempotency.Record, error) {
+	query := "SELECT " + idempotencyColumns + " FROM idempotency_keys WHERE scope = $1 AND idem_key = $2"
+	var rec domainidempotency.Record
+	err := ttx.QueryRow(ctx, query, scope, key).Scan(
+		&rec.Scope, &rec.Key, &rec.RequestHash, &rec.Status, &rec.ContentType, &rec.Body, &rec.CreatedAt, &rec.ExpiresAt,
+	)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &rec, nil
+}
+
+func (r *IdempotencyRepo) Update(ctx context.Context, ttx domain.Tx, rec *domainidempotency.Record) error {
+	n, err := ttx.Exec(ctx,
+		`UPDATE idempotency_keys
+		SET request_hash = $3, status = $4, content_type = $5, body = $6, created_at = $7, expires_at = $8
+		WHERE scope = $1 AND idem_key = $2`,
+		rec.Scope, rec.Key, rec.RequestHash, rec.Status, rec.ContentType, rec.Body, rec.CreatedAt, rec.ExpiresAt,
+	)
+	if err != nil {
+		return translateError(err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *IdempotencyRepo) Delete(ctx context.Context, ttx domain.Tx, scope, key string) error {
+	n, err := ttx.Exec(ctx, "DELETE FROM idempotency_keys WHERE scope = $1 AND idem_key = $2", scope, key)
+	if err != nil {
+		return translateError(err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *IdempotencyRepo) DeleteExpired(ctx context.Context, ttx domain.Tx, now time.Time, limit int) (int, error) {
+	n, err := ttx.Exec(ctx,
+		`DELETE FROM idempotency_keys
+		WHERE (scope, idem_key) IN (
+			SELECT scope, idem_key FROM idempotency_keys WHERE expires_at <= $1 LIMIT $2
+		)`,
+		now, limit,
+	)
+	if err != nil {
+		return 0, translateError(err)
+	}
+	return int(n), nil
+}
+
+var _ domainidempotency.Repository = (*IdempotencyRepo)(nil)
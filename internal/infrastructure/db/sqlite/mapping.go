@@ -0,0 +1,62 @@
+package sqlite
+
+import (
+	"context"
+
+	domain "github.com/user/reviewer-svc/internal/domain"
+	domainintegration "github.com/user/reviewer-svc/internal/domain/integration"
+)
+
+type MappingRepo struct{}
+
+func NewMappingRepo() *MappingRepo {
+	return &MappingRepo{}
+}
+
+func (r *MappingRepo) Upsert(ctx context.Context, ttx domain.Tx, m *domainintegration.UserMapping) error {
+	_, err := ttx.Exec(ctx,
+		`INSERT INTO external_user_mappings (provider, external_login, user_id, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (provider, external_login) DO UPDATE SET user_id = EXCLUDED.user_id`,
+		m.Provider, m.ExternalLogin, m.UserID, m.CreatedAt,
+	)
+	return translateError(err)
+}
+
+func (r *MappingRepo) Get(ctx context.Context, ttx domain.Tx, provider, login string) (*domainintegration.UserMapping, error) {
+	row := ttx.QueryRow(ctx,
+		"SELECT provider, external_login, user_id, created_at FROM external_user_mappings WHERE provider = $1 AND external_login = $2",
+		provider, login,
+	)
+	var m domainintegration.UserMapping
+	if err := row.Scan(&m.Provider, &m.ExternalLogin, &m.UserID, &m.CreatedAt); err != nil {
+		return nil, translateError(err)
+	}
+	return &m, nil
+}
+
+func (r *MappingRepo) List(ctx context.Context, ttx domain.Tx, provider string) ([]domainintegration.UserMapping, error) {
+	rows, err := ttx.Query(ctx,
+		"SELECT provider, external_login, user_id, created_at FROM external_user_mappings WHERE provider = $1 ORDER BY external_login",
+		provider,
+	)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	defer rows.Close()
+
+	var res []domainintegration.UserMapping
+	for rows.Next() {
+		var m domainintegration.UserMapping
+		if err := rows.Scan(&m.Provider, &m.ExternalLogin, &m.UserID, &m.CreatedAt); err != nil {
+			return nil, err
+		}
+		res = append(res, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+var _ domainintegration.MappingRepository = (*MappingRepo)(nil)
@@ -0,0 +1,514 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	domain "github.com/user/reviewer-svc/internal/domain"
+	domainpr "github.com/user/reviewer-svc/internal/domain/pr"
+	stats "github.com/user/reviewer-svc/internal/domain/stats"
+	userreassign "github.com/user/reviewer-svc/internal/domain/userreassign"
+)
+
+const (
+	statusOpenSmallint   int16 = 1
+	statusMergedSmallint int16 = 2
+	statusDraftSmallint  int16 = 3
+	statusClosedSmallint int16 = 4
+)
+
+type PRRepo struct{}
+
+func NewPRRepo() *PRRepo {
+	return &PRRepo{}
+}
+
+var (
+	_ domainpr.PullRequestRepository        = (*PRRepo)(nil)
+	_ domainpr.ReviewLoadRepository         = (*PRRepo)(nil)
+	_ stats.PullRequestStatsRepository      = (*PRRepo)(nil)
+	_ userreassign.ReassignmentPRRepository = (*PRRepo)(nil)
+)
+
+func (r *PRRepo) Create(ctx context.Context, ttx domain.Tx, pr *domainpr.PullRequest) error {
+	status, err := statusToSmallint(pr.Status)
+	if err != nil {
+		return err
+	}
+	_, err = ttx.Exec(ctx,
+		"INSERT INTO pull_requests (id, title, author_id, status, created_at, merged_at) VALUES ($1, $2, $3, $4, $5, $6)",
+		pr.ID, pr.Title, pr.AuthorID, status, pr.CreatedAt, pr.MergedAt,
+	)
+	if err != nil {
+		return translateError(err)
+	}
+	for _, rv := range pr.Reviewers {
+		_, err := ttx.Exec(ctx,
+			"INSERT INTO pr_reviewers (pr_id, slot, user_id, created_at, verdict, reviewed_at, over_capacity, fallback_team) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
+			pr.ID, rv.Slot, rv.UserID, rv.AssignedAt, verdictToNullable(rv.Verdict), rv.ReviewedAt, rv.OverCapacity, nullableString(rv.FallbackTeam),
+		)
+		if err != nil {
+			return translateError(err)
+		}
+	}
+	return nil
+}
+
+// GetByID ignores forUpdate: transactions share one connection and never
+// overlap.
+func (r *PRRepo) GetByID(ctx context.Context, ttx domain.Tx, id string, forUpdate bool) (*domainpr.PullRequest, error) {
+	row := ttx.QueryRow(ctx,
+		"SELECT id, title, author_id, status, created_at, merged_at FROM pull_requests WHERE id = $1",
+		id,
+	)
+	var pr domainpr.PullRequest
+	var statusSmall int16
+	if err := row.Scan(&pr.ID, &pr.Title, &pr.AuthorID, &statusSmall, &pr.CreatedAt, &pr.MergedAt); err != nil {
+		return nil, translateError(err)
+	}
+	status, err := statusFromSmallint(statusSmall)
+	if err != nil {
+		return nil, err
+	}
+	pr.Status = status
+
+	reviewers, err := r.loadReviewers(ctx, ttx, pr.ID)
+	if err != nil {
+		return nil, err
+	}
+	pr.Reviewers = reviewers
+	return &pr, nil
+}
+
+func (r *PRRepo) UpdateStatus(ctx context.Context, ttx domain.Tx, id string, status domainpr.PRStatus, mergedAt *time.Time) error {
+	code, err := statusToSmallint(status)
+	if err != nil {
+		return err
+	}
+	_, err = ttx.Exec(ctx,
+		"UPDATE pull_requests SET status = $1, merged_at = $2 WHERE id = $3",
+		code, mergedAt, id,
+	)
+	return translateError(err)
+}
+
+func (r *PRRepo) ReplaceReviewers(ctx context.Context, ttx domain.Tx, prID string, reviewers []domainpr.PRReviewer) error {
+	if _, err := ttx.Exec(ctx, "DELETE FROM pr_reviewers WHERE pr_id = $1", prID); err != nil {
+		return translateError(err)
+	}
+	for _, rv := range reviewers {
+		_, err := ttx.Exec(ctx,
+			"INSERT INTO pr_reviewers (pr_id, slot, user_id, created_at, verdict, reviewed_at, over_capacity, fallback_team) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
+			prID, rv.Slot, rv.UserID, rv.AssignedAt, verdictToNullable(rv.Verdict), rv.ReviewedAt, rv.OverCapacity, nullableString(rv.FallbackTeam),
+		)
+		if err != nil {
+			return translateError(err)
+		}
+	}
+	return nil
+}
+
+func (r *PRRepo) SetReviewVerdict(ctx context.Context, ttx domain.Tx, prID, userID string, verdict domainpr.ReviewVerdict, reviewedAt time.Time) error {
+	n, err := ttx.Exec(ctx,
+		"UPDATE pr_reviewers SET verdict = $1, reviewed_at = $2 WHERE pr_id = $3 AND user_id = $4",
+		string(verdict), reviewedAt, prID, userID,
+	)
+	if err != nil {
+		return translateError(err)
+	}
+	if n == 0 {
+		return domain.ErrBadReviewer
+	}
+	return nil
+}
+
+// SetChangedFiles records the paths the PR touches, replacing any recorded
+// before.
+func (r *PRRepo) SetChangedFiles(ctx context.Context, ttx domain.Tx, prID string, paths []string) error {
+	if _, err := ttx.Exec(ctx, "DELETE FROM pr_changed_files WHERE pr_id = $1", prID); err != nil {
+		return translateError(err)
+	}
+	for _, p := range paths {
+		if _, err := ttx.Exec(ctx, "INSERT INTO pr_changed_files (pr_id, path) VALUES ($1, $2)", prID, p); err != nil {
+			return translateError(err)
+		}
+	}
+	return nil
+}
+
+func (r *PRRepo) ChangedFiles(ctx context.Context, ttx domain.Tx, prID string) ([]string, error) {
+	rows, err := ttx.Query(ctx, "SELECT path FROM pr_changed_files WHERE pr_id = $1 ORDER BY path", prID)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	defer rows.Close()
+
+	var res []string
+	for rows.Next() {
+		var p string
+		if err := rows.Scan(&p); err != nil {
+			return nil, err
+		}
+		res = append(res, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SetRequiredTags records the skills the PR asks its reviewers to cover,
+// replacing any recorded before.
+func (r *PRRepo) SetRequiredTags(ctx context.Context, ttx domain.Tx, prID string, tags []string) error {
+	if _, err := ttx.Exec(ctx, "DELETE FROM pr_required_tags WHERE pr_id = $1", prID); err != nil {
+		return translateError(err)
+	}
+	for _, t := range tags {
+		if _, err := ttx.Exec(ctx, "INSERT INTO pr_required_tags (pr_id, tag) VALUES ($1, $2)", prID, t); err != nil {
+			return translateError(err)
+		}
+	}
+	return nil
+}
+
+func (r *PRRepo) RequiredTags(ctx context.Context, ttx domain.Tx, prID string) ([]string, error) {
+	rows, err := ttx.Query(ctx, "SELECT tag FROM pr_required_tags WHERE pr_id = $1 ORDER BY tag", prID)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	defer rows.Close()
+
+	var res []string
+	for rows.Next() {
+		var t string
+		if err := rows.Scan(&t); err != nil {
+			return nil, err
+		}
+		res = append(res, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *PRRepo) List(ctx context.Context, ttx domain.Tx, status *domainpr.PRStatus, page domain.PageRequest) (domain.Page[domainpr.PullRequest], error) {
+	query := "SELECT id, title, author_id, status, created_at, merged_at FROM pull_requests"
+	var args []any
+	var conds []string
+	if status != nil {
+		code, err := statusToSmallint(*status)
+		if err != nil {
+			return domain.Page[domainpr.PullRequest]{}, err
+		}
+		args = append(args, code)
+		conds = append(conds, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if page.Cursor != nil {
+		var cond string
+		cond, args = keysetCondition(page.Cursor, "", true, args)
+		conds = append(conds, cond)
+	}
+	if len(conds) > 0 {
+		query += " WHERE " + strings.Join(conds, " AND ")
+	}
+	order, args := keysetOrder(page, "", true, args)
+	query += order
+
+	res, err := r.queryPRs(ctx, ttx, query, args...)
+	if err != nil {
+		return domain.Page[domainpr.PullRequest]{}, err
+	}
+	return domain.NewPage(res, page, prCursor), nil
+}
+
+func (r *PRRepo) ListAssignedTo(ctx context.Context, ttx domain.Tx, userID string, status *domainpr.PRStatus, page domain.PageRequest) (domain.Page[domainpr.PullRequest], error) {
+	query := "SELECT DISTINCT p.id, p.title, p.author_id, p.status, p.created_at, p.merged_at FROM pull_requests p JOIN pr_reviewers r ON r.pr_id = p.id WHERE r.user_id = $1"
+	args := []any{userID}
+	if status != nil {
+		code, err := statusToSmallint(*status)
+		if err != nil {
+			return domain.Page[domainpr.PullRequest]{}, err
+		}
+		query += " AND p.status = $2"
+		args = append(args, code)
+	}
+	if page.Cursor != nil {
+		var cond string
+		cond, args = keysetCondition(page.Cursor, "p.", true, args)
+		query += " AND " + cond
+	}
+	order, args := keysetOrder(page, "p.", true, args)
+	query += order
+
+	res, err := r.queryPRs(ctx, ttx, query, args...)
+	if err != nil {
+		return domain.Page[domainpr.PullRequest]{}, err
+	}
+	return domain.NewPage(res, page, prCursor), nil
+}
+
+// queryPRs scans pull_requests rows and attaches their reviewers.
+func (r *PRRepo) queryPRs(ctx context.Context, ttx domain.Tx, query string, args ...any) ([]domainpr.PullRequest, error) {
+	rows, err := ttx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	defer rows.Close()
+
+	var res []domainpr.PullRequest
+	var ids []string
+	for rows.Next() {
+		var pr domainpr.PullRequest
+		var statusSmall int16
+		if err := rows.Scan(&pr.ID, &pr.Title, &pr.AuthorID, &statusSmall, &pr.CreatedAt, &pr.MergedAt); err != nil {
+			return nil, err
+		}
+		status, err := statusFromSmallint(statusSmall)
+		if err != nil {
+			return nil, err
+		}
+		pr.Status = status
+		ids = append(ids, pr.ID)
+		res = append(res, pr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	reviewersByPR, err := r.loadReviewersBulk(ctx, ttx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range res {
+		if rv, ok := reviewersByPR[res[i].ID]; ok {
+			res[i].Reviewers = rv
+		}
+	}
+	return res, nil
+}
+
+func prCursor(pr domainpr.PullRequest) domain.Cursor {
+	return domain.Cursor{CreatedAt: pr.CreatedAt, ID: pr.ID}
+}
+
+// CountOpenReviews returns the number of OPEN PRs each of userIDs reviews.
+// Users without open reviews are absent from the result.
+func (r *PRRepo) CountOpenReviews(ctx context.Context, ttx domain.Tx, userIDs []string) (map[string]int, error) {
+	res := make(map[string]int, len(userIDs))
+	if len(userIDs) == 0 {
+		return res, nil
+	}
+
+	query, args := buildStringInQuery(
+		"SELECT r.user_id, COUNT(*) FROM pr_reviewers r JOIN pull_requests p ON p.id = r.pr_id WHERE r.user_id IN (",
+		") AND p.status = $"+strconv.Itoa(len(userIDs)+1)+" GROUP BY r.user_id",
+		userIDs,
+	)
+	args = append(args, statusOpenSmallint)
+
+	rows, err := ttx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID string
+		var n int
+		if err := rows.Scan(&userID, &n); err != nil {
+			return nil, err
+		}
+		res[userID] = n
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *PRRepo) StatsByUser(ctx context.Context, ttx domain.Tx, teamID *string) ([]stats.UserAssignmentsStats, error) {
+	query := "SELECT u.id, COUNT(prr.pr_id) AS total," +
+		" COUNT(CASE WHEN p.status = $1 THEN 1 END) AS open_cnt," +
+		" COUNT(CASE WHEN p.status = $2 THEN 1 END) AS merged_cnt" +
+		" FROM users u" +
+		" LEFT JOIN pr_reviewers prr ON prr.user_id = u.id" +
+		" LEFT JOIN pull_requests p ON p.id = prr.pr_id"
+	args := []any{statusOpenSmallint, statusMergedSmallint}
+	if teamID != nil {
+		args = append(args, *teamID)
+		query += fmt.Sprintf(" WHERE u.team_id = $%d", len(args))
+	}
+	query += " GROUP BY u.id ORDER BY u.id"
+
+	rows, err := ttx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	defer rows.Close()
+
+	var res []stats.UserAssignmentsStats
+	for rows.Next() {
+		var s stats.UserAssignmentsStats
+		if err := rows.Scan(&s.UserID, &s.TotalAssigned, &s.OpenAssigned, &s.MergedAssigned); err != nil {
+			return nil, err
+		}
+		res = append(res, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *PRRepo) StatsByPR(ctx context.Context, ttx domain.Tx, teamID *string) ([]stats.PRAssignmentsStats, error) {
+	query := "SELECT p.id, COUNT(prr.user_id) AS reviewers_cnt FROM pull_requests p"
+	var args []any
+	if teamID != nil {
+		query += " JOIN users u ON p.author_id = u.id AND u.team_id = $1"
+		args = append(args, *teamID)
+	}
+	query += " LEFT JOIN pr_reviewers prr ON prr.pr_id = p.id"
+	query += " GROUP BY p.id ORDER BY p.id"
+
+	rows, err := ttx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	defer rows.Close()
+
+	var res []stats.PRAssignmentsStats
+	for rows.Next() {
+		var s stats.PRAssignmentsStats
+		if err := rows.Scan(&s.PRID, &s.ReviewersCount); err != nil {
+			return nil, err
+		}
+		res = append(res, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *PRRepo) loadReviewers(ctx context.Context, ttx domain.Tx, prID string) ([]domainpr.PRReviewer, error) {
+	rows, err := ttx.Query(ctx,
+		"SELECT pr_id, slot, user_id, created_at, verdict, reviewed_at, over_capacity, fallback_team FROM pr_reviewers WHERE pr_id = $1 ORDER BY slot",
+		prID,
+	)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	defer rows.Close()
+
+	var res []domainpr.PRReviewer
+	for rows.Next() {
+		var rv domainpr.PRReviewer
+		var verdict, fallbackTeam *string
+		if err := rows.Scan(&rv.PRID, &rv.Slot, &rv.UserID, &rv.AssignedAt, &verdict, &rv.ReviewedAt, &rv.OverCapacity, &fallbackTeam); err != nil {
+			return nil, err
+		}
+		if verdict != nil {
+			rv.Verdict = domainpr.ReviewVerdict(*verdict)
+		}
+		if fallbackTeam != nil {
+			rv.FallbackTeam = *fallbackTeam
+		}
+		res = append(res, rv)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *PRRepo) loadReviewersBulk(ctx context.Context, ttx domain.Tx, prIDs []string) (map[string][]domainpr.PRReviewer, error) {
+	if len(prIDs) == 0 {
+		return map[string][]domainpr.PRReviewer{}, nil
+	}
+
+	query, args := buildStringInQuery(
+		"SELECT pr_id, slot, user_id, created_at, verdict, reviewed_at, over_capacity, fallback_team FROM pr_reviewers WHERE pr_id IN (",
+		") ORDER BY pr_id, slot",
+		prIDs,
+	)
+
+	rows, err := ttx.Query(ctx,
+		query,
+		args...,
+	)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	defer rows.Close()
+
+	res := make(map[string][]domainpr.PRReviewer)
+	for rows.Next() {
+		var rv domainpr.PRReviewer
+		var verdict, fallbackTeam *string
+		if err := rows.Scan(&rv.PRID, &rv.Slot, &rv.UserID, &rv.AssignedAt, &verdict, &rv.ReviewedAt, &rv.OverCapacity, &fallbackTeam); err != nil {
+			return nil, err
+		}
+		if verdict != nil {
+			rv.Verdict = domainpr.ReviewVerdict(*verdict)
+		}
+		if fallbackTeam != nil {
+			rv.FallbackTeam = *fallbackTeam
+		}
+		res[rv.PRID] = append(res[rv.PRID], rv)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func verdictToNullable(v domainpr.ReviewVerdict) *string {
+	if v == "" {
+		return nil
+	}
+	s := string(v)
+	return &s
+}
+
+func nullableString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// statusToSmallint encodes s for the status column. Statuses it does not
+// know are rejected rather than stored as something else.
+func statusToSmallint(s domainpr.PRStatus) (int16, error) {
+	switch s {
+	case domainpr.PRStatusOpen:
+		return statusOpenSmallint, nil
+	case domainpr.PRStatusMerged:
+		return statusMergedSmallint, nil
+	case domainpr.PRStatusDraft:
+		return statusDraftSmallint, nil
+	case domainpr.PRStatusClosed:
+		return statusClosedSmallint, nil
+	default:
+		return 0, fmt.Errorf("unknown PR status %q", s)
+	}
+}
+
+func statusFromSmallint(v int16) (domainpr.PRStatus, error) {
+	switch v {
+	case statusOpenSmallint:
+		return domainpr.PRStatusOpen, nil
+	case statusMergedSmallint:
+		return domainpr.PRStatusMerged, nil
+	case statusDraftSmallint:
+		return domainpr.PRStatusDraft, nil
+	case statusClosedSmallint:
+		return domainpr.PRStatusClosed, nil
+	default:
+		return "", fmt.Errorf("unknown PR status code %d", v)
+	}
+}
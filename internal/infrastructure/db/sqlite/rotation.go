@@ -0,0 +1,54 @@
+package sqlite
+
+import (
+	"context"
+	"time"
+
+	domain "github.com/user/reviewer-svc/internal/domain"
+	domainuser "github.com/user/reviewer-svc/internal/domain/user"
+)
+
+type RotationRepo struct{}
+
+func NewRotationRepo() *RotationRepo {
+	return &RotationRepo{}
+}
+
+// LockCursor takes no lock: transactions share one connection and never
+// overlap.
+func (r *RotationRepo) LockCursor(ctx context.Context, ttx domain.Tx, teamID string) (domainuser.RotationCursor, error) {
+	if _, err := ttx.Exec(ctx,
+		"INSERT INTO team_rotation_cursors (team_id) VALUES ($1) ON CONFLICT (team_id) DO NOTHING",
+		teamID,
+	); err != nil {
+		return domainuser.RotationCursor{}, translateError(err)
+	}
+
+	var lastCreatedAt *time.Time
+	var lastUserID *string
+	row := ttx.QueryRow(ctx,
+		"SELECT last_created_at, last_user_id FROM team_rotation_cursors WHERE team_id = $1",
+		teamID,
+	)
+	if err := row.Scan(&lastCreatedAt, &lastUserID); err != nil {
+		return domainuser.RotationCursor{}, translateError(err)
+	}
+
+	c := domainuser.RotationCursor{TeamID: teamID}
+	if lastCreatedAt != nil && lastUserID != nil {
+		c.LastCreatedAt = *lastCreatedAt
+		c.LastUserID = *lastUserID
+	}
+	return c, nil
+}
+
+func (r *RotationRepo) SaveCursor(ctx context.Context, ttx domain.Tx, c domainuser.RotationCursor) error {
+	_, err := ttx.Exec(ctx,
+		`INSERT INTO team_rotation_cursors (team_id, last_created_at, last_user_id) VALUES ($1, $2, $3)
+		ON CONFLICT (team_id) DO UPDATE SET last_created_at = EXCLUDED.last_created_at, last_user_id = EXCLUDED.last_user_id`,
+		c.TeamID, c.LastCreatedAt, c.LastUserID,
+	)
+	return translateError(err)
+}
+
+var _ domainuser.RotationRepository = (*RotationRepo)(nil)
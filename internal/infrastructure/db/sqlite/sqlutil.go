@@ -0,0 +1,47 @@
+package sqlite
+
+import (
+	"strconv"
+	"strings"
+
+	domain "github.com/user/reviewer-svc/internal/domain"
+)
+
+func buildStringInQuery(prefix, suffix string, ids []string) (string, []any) {
+	args := make([]any, 0, len(ids))
+	placeholders := make([]string, 0, len(ids))
+	for i, id := range ids {
+		args = append(args, id)
+		placeholders = append(placeholders, "$"+strconv.Itoa(i+1))
+	}
+	query := prefix + strings.Join(placeholders, ",") + suffix
+	return query, args
+}
+
+// keysetCondition skips rows up to and including the page cursor. prefix is
+// the table alias (with trailing dot) or empty.
+func keysetCondition(cursor *domain.Cursor, prefix string, desc bool, args []any) (string, []any) {
+	op := ">"
+	if desc {
+		op = "<"
+	}
+	args = append(args, cursor.CreatedAt, cursor.ID)
+	cond := "(" + prefix + "created_at, " + prefix + "id) " + op +
+		" ($" + strconv.Itoa(len(args)-1) + ", $" + strconv.Itoa(len(args)) + ")"
+	return cond, args
+}
+
+// keysetOrder orders by (created_at, id) and fetches one extra row so that
+// domain.NewPage can tell whether another page follows.
+func keysetOrder(page domain.PageRequest, prefix string, desc bool, args []any) (string, []any) {
+	dir := "ASC"
+	if desc {
+		dir = "DESC"
+	}
+	clause := " ORDER BY " + prefix + "created_at " + dir + ", " + prefix + "id " + dir
+	if page.Limit > 0 {
+		args = append(args, page.Limit+1)
+		clause += " LIMIT $" + strconv.Itoa(len(args))
+	}
+	return clause, args
+}
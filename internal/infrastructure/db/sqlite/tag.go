@@ -0,0 +1,73 @@
+package sqlite
+
+import (
+	"context"
+
+	domain "github.com/user/reviewer-svc/internal/domain"
+	domainuser "github.com/user/reviewer-svc/internal/domain/user"
+)
+
+type TagRepo struct{}
+
+func NewTagRepo() *TagRepo {
+	return &TagRepo{}
+}
+
+// ListByUsers maps each of userIDs that has tags to them, sorted.
+func (r *TagRepo) ListByUsers(ctx context.Context, ttx domain.Tx, userIDs []string) (map[string][]string, error) {
+	res := make(map[string][]string)
+	if len(userIDs) == 0 {
+		return res, nil
+	}
+
+	query, args := buildStringInQuery("SELECT user_id, tag FROM user_tags WHERE user_id IN (", ") ORDER BY user_id, tag", userIDs)
+	rows, err := ttx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID, tag string
+		if err := rows.Scan(&userID, &tag); err != nil {
+			return nil, err
+		}
+		res[userID] = append(res[userID], tag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *TagRepo) Add(ctx context.Context, ttx domain.Tx, userID string, tags []string) error {
+	for _, t := range tags {
+		if _, err := ttx.Exec(ctx,
+			"INSERT INTO user_tags (user_id, tag) VALUES ($1, $2) ON CONFLICT (user_id, tag) DO NOTHING",
+			userID, t,
+		); err != nil {
+			return translateError(err)
+		}
+	}
+	return nil
+}
+
+func (r *TagRepo) Replace(ctx context.Context, ttx domain.Tx, userID string, tags []string) error {
+	if _, err := ttx.Exec(ctx, "DELETE FROM user_tags WHERE user_id = $1", userID); err != nil {
+		return translateError(err)
+	}
+	return r.Add(ctx, ttx, userID, tags)
+}
+
+func (r *TagRepo) Remove(ctx context.Context, ttx domain.Tx, userID, tag string) error {
+	n, err := ttx.Exec(ctx, "DELETE FROM user_tags WHERE user_id = $1 AND tag = $2", userID, tag)
+	if err != nil {
+		return translateError(err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+var _ domainuser.TagRepository = (*TagRepo)(nil)
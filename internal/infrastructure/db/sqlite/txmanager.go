@@ -0,0 +1,112 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	domain "github.com/user/reviewer-svc/internal/domain"
+	"github.com/user/reviewer-svc/internal/infrastructure/tracing"
+)
+
+// dbSystem is the OpenTelemetry name of the database.
+const dbSystem = "sqlite"
+
+type TxManager struct {
+	db *sql.DB
+}
+
+func NewTxManager(db *sql.DB) *TxManager {
+	return &TxManager{db: db}
+}
+
+func (m *TxManager) DB() *sql.DB {
+	return m.db
+}
+
+func (m *TxManager) Ping(ctx context.Context) error {
+	return m.db.PingContext(ctx)
+}
+
+// WithTx honours ReadOnly only: SQLite transactions are always
+// serializable, and with a single connection writers never conflict, so
+// there is nothing to retry.
+func (m *TxManager) WithTx(ctx context.Context, fn func(ctx context.Context, t domain.Tx) error, opts ...domain.TxOption) (err error) {
+	ctx, span := tracing.StartTx(ctx, dbSystem, 0)
+	defer func() { tracing.End(span, err) }()
+
+	o := domain.BuildTxOptions(opts...)
+	sqlTx, err := m.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: o.ReadOnly})
+	if err != nil {
+		return err
+	}
+
+	wrapped := &txWrapper{tx: sqlTx}
+
+	if err := fn(ctx, wrapped); err != nil {
+		if rbErr := sqlTx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx error: %w, rollback error: %v", err, rbErr)
+		}
+		return err
+	}
+
+	return sqlTx.Commit()
+}
+
+type txWrapper struct {
+	tx *sql.Tx
+}
+
+func (t *txWrapper) Exec(ctx context.Context, sql string, args ...any) (n int64, err error) {
+	ctx, span := tracing.StartQuery(ctx, dbSystem, sql)
+	defer func() { tracing.EndQuery(span, n, err) }()
+
+	res, err := t.tx.ExecContext(ctx, sql, utcArgs(args)...)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func (t *txWrapper) Query(ctx context.Context, sql string, args ...any) (domain.Rows, error) {
+	ctx, span := tracing.StartQuery(ctx, dbSystem, sql)
+	rows, err := t.tx.QueryContext(ctx, sql, utcArgs(args)...)
+	if err != nil {
+		tracing.EndQuery(span, 0, err)
+		return nil, err
+	}
+	return tracing.WrapRows(sqlRows{rows}, span), nil
+}
+
+func (t *txWrapper) QueryRow(ctx context.Context, sql string, args ...any) domain.Row {
+	ctx, span := tracing.StartQuery(ctx, dbSystem, sql)
+	return tracing.WrapRow(t.tx.QueryRowContext(ctx, sql, utcArgs(args)...), span)
+}
+
+type sqlRows struct {
+	*sql.Rows
+}
+
+func (r sqlRows) Close() {
+	_ = r.Rows.Close()
+}
+
+// utcArgs normalizes time arguments to UTC. Times are stored as text, so
+// comparisons are only correct when every value has the same offset.
+func utcArgs(args []any) []any {
+	for i, a := range args {
+		switch v := a.(type) {
+		case time.Time:
+			args[i] = v.UTC()
+		case *time.Time:
+			if v != nil {
+				utc := v.UTC()
+				args[i] = &utc
+			}
+		}
+	}
+	return args
+}
+
+var _ domain.TxManager = (*TxManager)(nil)
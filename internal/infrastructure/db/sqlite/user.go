@@ -0,0 +1,212 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	domain "github.com/user/reviewer-svc/internal/domain"
+	domainuser "github.com/user/reviewer-svc/internal/domain/user"
+	userreassign "github.com/user/reviewer-svc/internal/domain/userreassign"
+)
+
+type UserRepo struct{}
+
+func NewUserRepo() *UserRepo {
+	return &UserRepo{}
+}
+
+func (r *UserRepo) Create(ctx context.Context, ttx domain.Tx, u *domainuser.User) error {
+	_, err := ttx.Exec(ctx,
+		"INSERT INTO users (id, name, team_id, is_active, max_open_reviews, created_at) VALUES ($1, $2, $3, $4, $5, $6)",
+		u.ID, u.Name, u.TeamID, u.IsActive, u.MaxOpenReviews, u.CreatedAt,
+	)
+	return translateError(err)
+}
+
+func (r *UserRepo) Upsert(ctx context.Context, ttx domain.Tx, u *domainuser.User) error {
+	_, err := ttx.Exec(ctx,
+		`INSERT INTO users (id, name, team_id, is_active, created_at) 
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (id) DO UPDATE SET 
+			name = EXCLUDED.name, 
+			team_id = EXCLUDED.team_id, 
+			is_active = EXCLUDED.is_active`,
+		u.ID, u.Name, u.TeamID, u.IsActive, u.CreatedAt,
+	)
+	return translateError(err)
+}
+
+func (r *UserRepo) GetByID(ctx context.Context, ttx domain.Tx, id string) (*domainuser.User, error) {
+	row := ttx.QueryRow(ctx,
+		"SELECT id, name, team_id, is_active, max_open_reviews, created_at FROM users WHERE id = $1",
+		id,
+	)
+	var u domainuser.User
+	if err := row.Scan(&u.ID, &u.Name, &u.TeamID, &u.IsActive, &u.MaxOpenReviews, &u.CreatedAt); err != nil {
+		return nil, translateError(err)
+	}
+	return &u, nil
+}
+
+func (r *UserRepo) Update(ctx context.Context, ttx domain.Tx, u *domainuser.User) error {
+	_, err := ttx.Exec(ctx,
+		"UPDATE users SET name = $1, is_active = $2, max_open_reviews = $3 WHERE id = $4",
+		u.Name, u.IsActive, u.MaxOpenReviews, u.ID,
+	)
+	return translateError(err)
+}
+
+func (r *UserRepo) List(ctx context.Context, ttx domain.Tx, teamID *string, isActive *bool, page domain.PageRequest) (domain.Page[domainuser.User], error) {
+	query := "SELECT id, name, team_id, is_active, max_open_reviews, created_at FROM users"
+	var args []any
+	var conds []string
+
+	if teamID != nil {
+		args = append(args, *teamID)
+		conds = append(conds, fmt.Sprintf("team_id = $%d", len(args)))
+	}
+	if isActive != nil {
+		args = append(args, *isActive)
+		conds = append(conds, fmt.Sprintf("is_active = $%d", len(args)))
+	}
+	if page.Cursor != nil {
+		var cond string
+		cond, args = keysetCondition(page.Cursor, "", false, args)
+		conds = append(conds, cond)
+	}
+	if len(conds) > 0 {
+		query += " WHERE " + strings.Join(conds, " AND ")
+	}
+	order, args := keysetOrder(page, "", false, args)
+	query += order
+
+	rows, err := ttx.Query(ctx, query, args...)
+	if err != nil {
+		return domain.Page[domainuser.User]{}, translateError(err)
+	}
+	defer rows.Close()
+
+	var res []domainuser.User
+	for rows.Next() {
+		var u domainuser.User
+		if err := rows.Scan(&u.ID, &u.Name, &u.TeamID, &u.IsActive, &u.MaxOpenReviews, &u.CreatedAt); err != nil {
+			return domain.Page[domainuser.User]{}, err
+		}
+		res = append(res, u)
+	}
+	if err := rows.Err(); err != nil {
+		return domain.Page[domainuser.User]{}, err
+	}
+	return domain.NewPage(res, page, userCursor), nil
+}
+
+func userCursor(u domainuser.User) domain.Cursor {
+	return domain.Cursor{CreatedAt: u.CreatedAt, ID: u.ID}
+}
+
+func (r *UserRepo) ListByIDs(ctx context.Context, ttx domain.Tx, ids []string) ([]domainuser.User, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	query, args := buildStringInQuery("SELECT id, name, team_id, is_active, max_open_reviews, created_at FROM users WHERE id IN (", ")", ids)
+
+	rows, err := ttx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	defer rows.Close()
+
+	var res []domainuser.User
+	for rows.Next() {
+		var u domainuser.User
+		if err := rows.Scan(&u.ID, &u.Name, &u.TeamID, &u.IsActive, &u.MaxOpenReviews, &u.CreatedAt); err != nil {
+			return nil, err
+		}
+		res = append(res, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ListActiveByTeamExcept returns active team members that are not on an
+// absence covering at.
+func (r *UserRepo) ListActiveByTeamExcept(ctx context.Context, ttx domain.Tx, teamID string, exclude []string, at time.Time) ([]domainuser.User, error) {
+	query := "SELECT id, name, team_id, is_active, max_open_reviews, created_at FROM users WHERE team_id = $1 AND is_active = TRUE" +
+		" AND NOT EXISTS (SELECT 1 FROM user_absences a WHERE a.user_id = users.id AND a.starts_at <= $2 AND a.ends_at > $2)"
+	args := []any{teamID, at}
+
+	if len(exclude) > 0 {
+		var placeholders []string
+		for _, id := range exclude {
+			args = append(args, id)
+			placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+		}
+		query += " AND id NOT IN (" + strings.Join(placeholders, ",") + ")"
+	}
+	query += " ORDER BY created_at"
+
+	rows, err := ttx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	defer rows.Close()
+
+	var res []domainuser.User
+	for rows.Next() {
+		var u domainuser.User
+		if err := rows.Scan(&u.ID, &u.Name, &u.TeamID, &u.IsActive, &u.MaxOpenReviews, &u.CreatedAt); err != nil {
+			return nil, err
+		}
+		res = append(res, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ListActiveByIDs returns those of ids that are active users and not on an
+// absence covering at.
+func (r *UserRepo) ListActiveByIDs(ctx context.Context, ttx domain.Tx, ids []string, at time.Time) ([]domainuser.User, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	args := []any{at}
+	placeholders := make([]string, 0, len(ids))
+	for _, id := range ids {
+		args = append(args, id)
+		placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+	}
+	query := "SELECT id, name, team_id, is_active, max_open_reviews, created_at FROM users WHERE is_active = TRUE" +
+		" AND NOT EXISTS (SELECT 1 FROM user_absences a WHERE a.user_id = users.id AND a.starts_at <= $1 AND a.ends_at > $1)" +
+		" AND id IN (" + strings.Join(placeholders, ",") + ") ORDER BY created_at"
+
+	rows, err := ttx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	defer rows.Close()
+
+	var res []domainuser.User
+	for rows.Next() {
+		var u domainuser.User
+		if err := rows.Scan(&u.ID, &u.Name, &u.TeamID, &u.IsActive, &u.MaxOpenReviews, &u.CreatedAt); err != nil {
+			return nil, err
+		}
+		res = append(res, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+var _ domainuser.UserRepository = (*UserRepo)(nil)
+var _ domainuser.BulkUserRepository = (*UserRepo)(nil)
+var _ userreassign.ReassignmentUserRepository = (*UserRepo)(nil)
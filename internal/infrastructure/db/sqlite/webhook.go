@@ -0,0 +1,198 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	domain "github.com/user/reviewer-svc/internal/domain"
+	domainwebhook "github.com/user/reviewer-svc/internal/domain/webhook"
+)
+
+type WebhookRepo struct{}
+
+func NewWebhookRepo() *WebhookRepo {
+	return &WebhookRepo{}
+}
+
+func (r *WebhookRepo) Enqueue(ctx context.Context, ttx domain.Tx, e domainwebhook.Event) error {
+	payload, err := json.Marshal(e.Payload)
+	if err != nil {
+		return err
+	}
+
+	var outboxID int64
+	row := ttx.QueryRow(ctx,
+		"INSERT INTO webhook_outbox (event_type, payload, occurred_at) VALUES ($1, $2, $3) RETURNING id",
+		string(e.Type), string(payload), e.OccurredAt,
+	)
+	if err := row.Scan(&outboxID); err != nil {
+		return translateError(err)
+	}
+
+	_, err = ttx.Exec(ctx,
+		"INSERT INTO webhook_deliveries (outbox_id, subscription_id, status, attempts, next_attempt_at)"+
+			" SELECT $1, id, 'PENDING', 0, $2 FROM webhook_subscriptions"+
+			" WHERE is_active AND EXISTS (SELECT 1 FROM json_each(events) WHERE value = $3)",
+		outboxID, e.OccurredAt, string(e.Type),
+	)
+	return translateError(err)
+}
+
+func (r *WebhookRepo) CreateSubscription(ctx context.Context, ttx domain.Tx, s *domainwebhook.Subscription) error {
+	events, err := json.Marshal(s.Events)
+	if err != nil {
+		return err
+	}
+	_, err = ttx.Exec(ctx,
+		"INSERT INTO webhook_subscriptions (id, url, secret, events, is_active, created_at) VALUES ($1, $2, $3, $4, $5, $6)",
+		s.ID, s.URL, s.Secret, string(events), s.IsActive, s.CreatedAt,
+	)
+	return translateError(err)
+}
+
+func (r *WebhookRepo) ListSubscriptions(ctx context.Context, ttx domain.Tx) ([]domainwebhook.Subscription, error) {
+	rows, err := ttx.Query(ctx,
+		"SELECT id, url, secret, events, is_active, created_at FROM webhook_subscriptions ORDER BY created_at",
+	)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	defer rows.Close()
+
+	var res []domainwebhook.Subscription
+	for rows.Next() {
+		var s domainwebhook.Subscription
+		var events string
+		if err := rows.Scan(&s.ID, &s.URL, &s.Secret, &events, &s.IsActive, &s.CreatedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(events), &s.Events); err != nil {
+			return nil, err
+		}
+		res = append(res, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *WebhookRepo) DeleteSubscription(ctx context.Context, ttx domain.Tx, id string) error {
+	n, err := ttx.Exec(ctx, "DELETE FROM webhook_subscriptions WHERE id = $1", id)
+	if err != nil {
+		return translateError(err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *WebhookRepo) ListDeadLetters(ctx context.Context, ttx domain.Tx) ([]domainwebhook.Delivery, error) {
+	rows, err := ttx.Query(ctx,
+		"SELECT id, subscription_id, url, outbox_id, event_type, payload, occurred_at, attempts, next_attempt_at, last_error"+
+			" FROM webhook_dead_letters ORDER BY id",
+	)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	defer rows.Close()
+
+	var res []domainwebhook.Delivery
+	for rows.Next() {
+		var d domainwebhook.Delivery
+		var eventType string
+		if err := rows.Scan(&d.ID, &d.SubscriptionID, &d.URL, &d.EventID, &eventType, &d.Payload, &d.OccurredAt, &d.Attempts, &d.NextAttemptAt, &d.LastError); err != nil {
+			return nil, err
+		}
+		d.EventType = domainwebhook.EventType(eventType)
+		d.Status = domainwebhook.DeliveryDead
+		res = append(res, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *WebhookRepo) RetryDelivery(ctx context.Context, ttx domain.Tx, id int64, at time.Time) error {
+	n, err := ttx.Exec(ctx,
+		"UPDATE webhook_deliveries SET status = 'PENDING', attempts = 0, next_attempt_at = $1 WHERE id = $2 AND status = 'DEAD'",
+		at, id,
+	)
+	if err != nil {
+		return translateError(err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// ClaimDue leases due deliveries until leaseUntil. There is no SKIP LOCKED in
+// SQLite; transactions never overlap, so selecting and then updating is safe.
+func (r *WebhookRepo) ClaimDue(ctx context.Context, ttx domain.Tx, now time.Time, leaseUntil time.Time, limit int) ([]domainwebhook.Delivery, error) {
+	rows, err := ttx.Query(ctx,
+		"SELECT d.id, d.subscription_id, s.url, s.secret, o.id, o.event_type, o.payload, o.occurred_at, d.attempts"+
+			" FROM webhook_deliveries d"+
+			" JOIN webhook_outbox o ON o.id = d.outbox_id"+
+			" JOIN webhook_subscriptions s ON s.id = d.subscription_id"+
+			" WHERE d.status = 'PENDING' AND d.next_attempt_at <= $1"+
+			" ORDER BY d.next_attempt_at, d.id LIMIT $2",
+		now, limit,
+	)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	defer rows.Close()
+
+	var res []domainwebhook.Delivery
+	for rows.Next() {
+		var d domainwebhook.Delivery
+		var eventType string
+		if err := rows.Scan(&d.ID, &d.SubscriptionID, &d.URL, &d.Secret, &d.EventID, &eventType, &d.Payload, &d.OccurredAt, &d.Attempts); err != nil {
+			return nil, err
+		}
+		d.EventType = domainwebhook.EventType(eventType)
+		d.Status = domainwebhook.DeliveryPending
+		d.NextAttemptAt = leaseUntil
+		res = append(res, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	for _, d := range res {
+		_, err := ttx.Exec(ctx, "UPDATE webhook_deliveries SET next_attempt_at = $1 WHERE id = $2", leaseUntil, d.ID)
+		if err != nil {
+			return nil, translateError(err)
+		}
+	}
+	return res, nil
+}
+
+func (r *WebhookRepo) MarkDelivered(ctx context.Context, ttx domain.Tx, id int64, at time.Time) error {
+	_, err := ttx.Exec(ctx,
+		"UPDATE webhook_deliveries SET status = 'DELIVERED', attempts = attempts + 1, delivered_at = $1, last_error = NULL WHERE id = $2",
+		at, id,
+	)
+	return translateError(err)
+}
+
+func (r *WebhookRepo) MarkFailed(ctx context.Context, ttx domain.Tx, id int64, attempts int, nextAttemptAt time.Time, lastErr string, dead bool) error {
+	status := string(domainwebhook.DeliveryPending)
+	if dead {
+		status = string(domainwebhook.DeliveryDead)
+	}
+	_, err := ttx.Exec(ctx,
+		"UPDATE webhook_deliveries SET status = $1, attempts = $2, next_attempt_at = $3, last_error = $4 WHERE id = $5",
+		status, attempts, nextAttemptAt, lastErr, id,
+	)
+	return translateError(err)
+}
+
+var _ domainwebhook.Outbox = (*WebhookRepo)(nil)
+var _ domainwebhook.Repository = (*WebhookRepo)(nil)
+var _ domainwebhook.DeliveryRepository = (*WebhookRepo)(nil)
@@ -0,0 +1,107 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/user/reviewer-svc/internal/domain"
+)
+
+const namespace = "reviewer"
+
+// Metrics owns a Prometheus registry with the HTTP, database and domain
+// metrics of one service instance.
+type Metrics struct {
+	reg *prometheus.Registry
+
+	httpDuration *prometheus.HistogramVec
+	pools        *poolCollector
+
+	prsCreated        prometheus.Counter
+	reviewersAssigned *prometheus.CounterVec
+	reassignments     *prometheus.CounterVec
+	noCandidate       prometheus.Counter
+	merges            prometheus.Counter
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		reg: prometheus.NewRegistry(),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by route pattern and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		pools: &poolCollector{pools: map[string]*pgxpool.Pool{}},
+		prsCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "prs_created_total",
+			Help:      "Pull requests created.",
+		}),
+		reviewersAssigned: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reviewers_assigned_total",
+			Help:      "Reviewers assigned to newly created pull requests, by author team.",
+		}, []string{"team"}),
+		reassignments: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reassignments_total",
+			Help:      "Reviewer replacements by cause.",
+		}, []string{"cause"}),
+		noCandidate: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "no_candidate_total",
+			Help:      "Operations that failed because no reviewer candidate was available.",
+		}),
+		merges: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "prs_merged_total",
+			Help:      "Pull requests merged.",
+		}),
+	}
+
+	m.reg.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpDuration,
+		m.pools,
+		m.prsCreated,
+		m.reviewersAssigned,
+		m.reassignments,
+		m.noCandidate,
+		m.merges,
+	)
+	return m
+}
+
+// Handler serves the registry in the Prometheus text format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.reg, promhttp.HandlerOpts{})
+}
+
+func (m *Metrics) PRCreated() {
+	m.prsCreated.Inc()
+}
+
+func (m *Metrics) ReviewersAssigned(team string, n int) {
+	m.reviewersAssigned.WithLabelValues(team).Add(float64(n))
+}
+
+func (m *Metrics) Reassigned(cause domain.ReassignCause, n int) {
+	m.reassignments.WithLabelValues(string(cause)).Add(float64(n))
+}
+
+func (m *Metrics) NoCandidate() {
+	m.noCandidate.Inc()
+}
+
+func (m *Metrics) PRMerged() {
+	m.merges.Inc()
+}
+
+var _ domain.Metrics = (*Metrics)(nil)
@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	poolAcquiredConns = prometheus.NewDesc(namespace+"_db_pool_acquired_conns", "Connections currently in use.", []string{"pool"}, nil)
+	poolIdleConns     = prometheus.NewDesc(namespace+"_db_pool_idle_conns", "Idle connections.", []string{"pool"}, nil)
+	poolTotalConns    = prometheus.NewDesc(namespace+"_db_pool_total_conns", "Open connections.", []string{"pool"}, nil)
+	poolMaxConns      = prometheus.NewDesc(namespace+"_db_pool_max_conns", "Maximum pool size.", []string{"pool"}, nil)
+	poolAcquires      = prometheus.NewDesc(namespace+"_db_pool_acquires_total", "Successful connection acquisitions.", []string{"pool"}, nil)
+	poolEmptyAcquires = prometheus.NewDesc(namespace+"_db_pool_empty_acquires_total", "Acquisitions that had to wait for a connection.", []string{"pool"}, nil)
+	poolCanceled      = prometheus.NewDesc(namespace+"_db_pool_canceled_acquires_total", "Acquisitions canceled by their context.", []string{"pool"}, nil)
+	poolAcquireWait   = prometheus.NewDesc(namespace+"_db_pool_acquire_duration_seconds_total", "Time spent acquiring connections.", []string{"pool"}, nil)
+)
+
+// poolCollector reads the statistics of every registered pgxpool at scrape
+// time.
+type poolCollector struct {
+	mu    sync.Mutex
+	pools map[string]*pgxpool.Pool
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- poolAcquiredConns
+	ch <- poolIdleConns
+	ch <- poolTotalConns
+	ch <- poolMaxConns
+	ch <- poolAcquires
+	ch <- poolEmptyAcquires
+	ch <- poolCanceled
+	ch <- poolAcquireWait
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for name, pool := range c.pools {
+		collectPool(ch, name, pool.Stat())
+	}
+}
+
+func collectPool(ch chan<- prometheus.Metric, name string, st *pgxpool.Stat) {
+	ch <- prometheus.MustNewConstMetric(poolAcquiredConns, prometheus.GaugeValue, float64(st.AcquiredConns()), name)
+	ch <- prometheus.MustNewConstMetric(poolIdleConns, prometheus.GaugeValue, float64(st.IdleConns()), name)
+	ch <- prometheus.MustNewConstMetric(poolTotalConns, prometheus.GaugeValue, float64(st.TotalConns()), name)
+	ch <- prometheus.MustNewConstMetric(poolMaxConns, prometheus.GaugeValue, float64(st.MaxConns()), name)
+	ch <- prometheus.MustNewConstMetric(poolAcquires, prometheus.CounterValue, float64(st.AcquireCount()), name)
+	ch <- prometheus.MustNewConstMetric(poolEmptyAcquires, prometheus.CounterValue, float64(st.EmptyAcquireCount()), name)
+	ch <- prometheus.MustNewConstMetric(poolCanceled, prometheus.CounterValue, float64(st.CanceledAcquireCount()), name)
+	ch <- prometheus.MustNewConstMetric(poolAcquireWait, prometheus.CounterValue, st.AcquireDuration().Seconds(), name)
+}
+
+// RegisterPool exports the statistics of pool under the given pool label.
+func (m *Metrics) RegisterPool(name string, pool *pgxpool.Pool) {
+	m.pools.mu.Lock()
+	defer m.pools.mu.Unlock()
+	m.pools.pools[name] = pool
+}
@@ -0,0 +1,201 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// minRefreshInterval limits how often a token with an unknown key ID can
+// make the set reload, so that junk tokens cannot hammer the JWKS endpoint.
+const minRefreshInterval = time.Minute
+
+var errUnknownKey = errors.New("unknown signing key")
+
+// KeySet is a cached JSON Web Key Set read from a file or fetched from a
+// URL. It is reloaded once ttl has passed and when a token names a key it
+// does not know, which picks up key rotation without a restart.
+type KeySet struct {
+	load func(ctx context.Context) ([]byte, error)
+	ttl  time.Duration
+
+	mu        sync.Mutex
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+	triedAt   time.Time
+}
+
+// NewFileKeySet reads the set from path.
+func NewFileKeySet(path string, ttl time.Duration) *KeySet {
+	return &KeySet{ttl: ttl, load: func(context.Context) ([]byte, error) {
+		return os.ReadFile(path)
+	}}
+}
+
+// NewURLKeySet fetches the set from url.
+func NewURLKeySet(url string, ttl time.Duration) *KeySet {
+	client := &http.Client{Timeout: 5 * time.Second}
+	return &KeySet{ttl: ttl, load: func(ctx context.Context) ([]byte, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		res, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer res.Body.Close()
+		if res.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("fetch JWKS: unexpected status %d", res.StatusCode)
+		}
+		return io.ReadAll(io.LimitReader(res.Body, 1<<20))
+	}}
+}
+
+// Key returns the public key with ID kid. An empty kid is accepted when the
+// set holds a single key.
+func (s *KeySet) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.keys == nil || time.Since(s.fetchedAt) > s.ttl {
+		if err := s.refresh(ctx); err != nil && s.keys == nil {
+			return nil, err
+		}
+	}
+	if key, ok := s.lookup(kid); ok {
+		return key, nil
+	}
+	if err := s.refresh(ctx); err != nil {
+		return nil, err
+	}
+	if key, ok := s.lookup(kid); ok {
+		return key, nil
+	}
+	return nil, errUnknownKey
+}
+
+func (s *KeySet) lookup(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key, true
+		}
+	}
+	key, ok := s.keys[kid]
+	return key, ok
+}
+
+// refresh replaces the cached keys, at most once per minRefreshInterval
+// while there are keys to fall back on. On failure the previous keys stay
+// in use, so a flaky JWKS endpoint does not lock everybody out.
+func (s *KeySet) refresh(ctx context.Context) error {
+	if s.keys != nil && time.Since(s.triedAt) < minRefreshInterval {
+		return nil
+	}
+	s.triedAt = time.Now()
+
+	raw, err := s.load(ctx)
+	if err != nil {
+		return fmt.Errorf("load JWKS: %w", err)
+	}
+	keys, err := parseKeySet(raw)
+	if err != nil {
+		return fmt.Errorf("parse JWKS: %w", err)
+	}
+	s.keys = keys
+	s.fetchedAt = s.triedAt
+	return nil
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// parseKeySet keeps the RSA and P-256 signing keys of a set and skips the
+// rest.
+func parseKeySet(raw []byte) (map[string]crypto.PublicKey, error) {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(raw, &set); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		var (
+			key crypto.PublicKey
+			err error
+		)
+		switch k.Kty {
+		case "RSA":
+			key, err = parseRSAKey(k)
+		case "EC":
+			if k.Crv != "P-256" {
+				continue
+			}
+			key, err = parseP256Key(k)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", k.Kid, err)
+		}
+		keys[k.Kid] = key
+	}
+	return keys, nil
+}
+
+func parseRSAKey(k jsonWebKey) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, err
+	}
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, err
+	}
+	exp := new(big.Int).SetBytes(e)
+	if len(n) == 0 || !exp.IsInt64() || exp.Int64() < 3 || exp.Int64() > 1<<31-1 {
+		return nil, errors.New("malformed RSA key")
+	}
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exp.Int64())}, nil
+}
+
+func parseP256Key(k jsonWebKey) (*ecdsa.PublicKey, error) {
+	x, err := base64.RawURLEncoding.DecodeString(k.X)
+	if err != nil {
+		return nil, err
+	}
+	y, err := base64.RawURLEncoding.DecodeString(k.Y)
+	if err != nil {
+		return nil, err
+	}
+	if len(x) != 32 || len(y) != 32 {
+		return nil, errors.New("malformed P-256 key")
+	}
+	point := append(append([]byte{4}, x...), y...)
+	return ecdsa.ParseUncompressedPublicKey(elliptic.P256(), point)
+}
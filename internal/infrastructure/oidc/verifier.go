@@ -0,0 +1,100 @@
+package oidc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/user/reviewer-svc/internal/domain"
+)
+
+// leeway tolerates clock skew between the identity provider and us.
+const leeway = 30 * time.Second
+
+type Config struct {
+	// Issuer and Audience are checked against the iss and aud claims when
+	// set.
+	Issuer   string
+	Audience string
+	// UserClaim holds the users.id of the caller, "sub" by default;
+	// RolesClaim holds a role name or a list of them, "roles" by default.
+	UserClaim  string
+	RolesClaim string
+}
+
+// Verifier checks RS256 and ES256 bearer tokens signed by a key of its set
+// and turns them into principals.
+type Verifier struct {
+	keys   *KeySet
+	cfg    Config
+	parser *jwt.Parser
+}
+
+func NewVerifier(keys *KeySet, cfg Config) *Verifier {
+	if cfg.UserClaim == "" {
+		cfg.UserClaim = "sub"
+	}
+	if cfg.RolesClaim == "" {
+		cfg.RolesClaim = "roles"
+	}
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(leeway),
+	}
+	if cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+	return &Verifier{keys: keys, cfg: cfg, parser: jwt.NewParser(opts...)}
+}
+
+// Verify validates token and returns who it was issued to. All failures
+// wrap domain.ErrUnauthenticated.
+func (v *Verifier) Verify(ctx context.Context, token string) (domain.Principal, error) {
+	claims := jwt.MapClaims{}
+	_, err := v.parser.ParseWithClaims(token, claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		return v.keys.Key(ctx, kid)
+	})
+	if err != nil {
+		return domain.Principal{}, fmt.Errorf("%w: %v", domain.ErrUnauthenticated, err)
+	}
+
+	userID, _ := claims[v.cfg.UserClaim].(string)
+	if userID == "" {
+		return domain.Principal{}, fmt.Errorf("%w: token has no %s claim", domain.ErrUnauthenticated, v.cfg.UserClaim)
+	}
+	return domain.Principal{UserID: userID, Role: highestRole(claims[v.cfg.RolesClaim])}, nil
+}
+
+// highestRole picks the most privileged known role from a claim holding a
+// single name or a list; anything else makes the caller a plain member.
+func highestRole(claim any) domain.Role {
+	var names []string
+	switch c := claim.(type) {
+	case string:
+		names = []string{c}
+	case []any:
+		for _, n := range c {
+			if s, ok := n.(string); ok {
+				names = append(names, s)
+			}
+		}
+	}
+
+	role := domain.RoleMember
+	for _, n := range names {
+		switch domain.Role(n) {
+		case domain.RoleAdmin:
+			return domain.RoleAdmin
+		case domain.RoleTeamLead:
+			role = domain.RoleTeamLead
+		}
+	}
+	return role
+}
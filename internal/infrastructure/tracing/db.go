@@ -0,0 +1,125 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/user/reviewer-svc/internal/domain"
+)
+
+// rowsKey is the row count of a query: rows returned for reads, rows
+// affected for writes.
+const rowsKey = attribute.Key("db.rows")
+
+// StartTx starts the span of one WithTx attempt on the given database
+// system, e.g. "postgresql".
+func StartTx(ctx context.Context, system string, attempt int) (context.Context, trace.Span) {
+	return tracer.Start(ctx, "db.tx",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemNameKey.String(system),
+			attribute.Int("db.tx.attempt", attempt),
+		),
+	)
+}
+
+// End finishes span, marking it failed when err is set.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// StartQuery starts the span of one SQL statement; the statement text is
+// recorded as is, without arguments.
+func StartQuery(ctx context.Context, system, query string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, operation(query),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemNameKey.String(system),
+			semconv.DBQueryText(query),
+		),
+	)
+}
+
+// EndQuery records the row count and finishes span.
+func EndQuery(span trace.Span, rows int64, err error) {
+	span.SetAttributes(rowsKey.Int64(rows))
+	End(span, err)
+}
+
+// WrapRows counts the rows read from rows and ends span when they are
+// closed.
+func WrapRows(rows domain.Rows, span trace.Span) domain.Rows {
+	return &tracedRows{Rows: rows, span: span}
+}
+
+type tracedRows struct {
+	domain.Rows
+	span trace.Span
+	n    int64
+	done bool
+}
+
+func (r *tracedRows) Next() bool {
+	if r.Rows.Next() {
+		r.n++
+		return true
+	}
+	return false
+}
+
+func (r *tracedRows) Close() {
+	r.Rows.Close()
+	if !r.done {
+		r.done = true
+		EndQuery(r.span, r.n, r.Rows.Err())
+	}
+}
+
+// WrapRow ends span once the single row has been scanned. A missing row is
+// not an error of the query itself.
+func WrapRow(row domain.Row, span trace.Span) domain.Row {
+	return tracedRow{Row: row, span: span}
+}
+
+type tracedRow struct {
+	domain.Row
+	span trace.Span
+}
+
+func (r tracedRow) Scan(dest ...any) error {
+	err := r.Row.Scan(dest...)
+	switch {
+	case err == nil:
+		EndQuery(r.span, 1, nil)
+	case errors.Is(err, sql.ErrNoRows):
+		EndQuery(r.span, 0, nil)
+	default:
+		EndQuery(r.span, 0, err)
+	}
+	return err
+}
+
+// operation names a query span after its leading keyword, such as SELECT or
+// INSERT, so that spans group well without high-cardinality names.
+func operation(query string) string {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return "db.query"
+	}
+	op := strings.ToUpper(fields[0])
+	if op == "WITH" {
+		return "db.query"
+	}
+	return op
+}
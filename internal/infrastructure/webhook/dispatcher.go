@@ -0,0 +1,152 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/user/reviewer-svc/internal/domain"
+	domainwebhook "github.com/user/reviewer-svc/internal/domain/webhook"
+)
+
+type Config struct {
+	PollInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int
+	BackoffBase  time.Duration
+	BackoffMax   time.Duration
+	Timeout      time.Duration
+}
+
+// Dispatcher delivers pending outbox events to subscribers. Deliveries are
+// claimed with a lease so that several instances can run side by side.
+type Dispatcher struct {
+	repo   domainwebhook.DeliveryRepository
+	tx     domain.TxManager
+	clk    domain.Clock
+	client *http.Client
+	cfg    Config
+	log    *slog.Logger
+}
+
+type envelope struct {
+	ID         int64           `json:"id"`
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+func NewDispatcher(repo domainwebhook.DeliveryRepository, tx domain.TxManager, clk domain.Clock, cfg Config, log *slog.Logger) *Dispatcher {
+	return &Dispatcher{
+		repo:   repo,
+		tx:     tx,
+		clk:    clk,
+		client: &http.Client{Timeout: cfg.Timeout},
+		cfg:    cfg,
+		log:    log,
+	}
+}
+
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := d.ProcessBatch(ctx); err != nil && ctx.Err() == nil {
+			d.log.Error("webhook dispatch failed", "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessBatch delivers up to BatchSize due deliveries and returns how many
+// were attempted.
+func (d *Dispatcher) ProcessBatch(ctx context.Context) (int, error) {
+	now := d.clk.Now()
+	// Deliveries are sent one after another, so the lease has to outlast a
+	// whole batch of timed out requests: once it expires another instance
+	// claims the deliveries still waiting here and sends them twice. The
+	// extra Timeout leaves room for recording the results.
+	leaseUntil := now.Add(d.cfg.Timeout * time.Duration(d.cfg.BatchSize+1))
+
+	var due []domainwebhook.Delivery
+	err := d.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
+		list, err := d.repo.ClaimDue(ctx, ttx, now, leaseUntil, d.cfg.BatchSize)
+		if err != nil {
+			return err
+		}
+		due = list
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, dl := range due {
+		sendErr := d.send(ctx, dl)
+		if err := d.record(ctx, dl, sendErr); err != nil {
+			return 0, err
+		}
+	}
+	return len(due), nil
+}
+
+func (d *Dispatcher) send(ctx context.Context, dl domainwebhook.Delivery) error {
+	body, err := json.Marshal(envelope{
+		ID:         dl.EventID,
+		Type:       string(dl.EventType),
+		OccurredAt: dl.OccurredAt,
+		Data:       dl.Payload,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, dl.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Event", string(dl.EventType))
+	req.Header.Set("X-Webhook-Delivery", strconv.FormatInt(dl.ID, 10))
+	req.Header.Set(domainwebhook.SignatureHeader, domainwebhook.Sign(dl.Secret, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (d *Dispatcher) record(ctx context.Context, dl domainwebhook.Delivery, sendErr error) error {
+	now := d.clk.Now()
+	return d.tx.WithTx(ctx, func(ctx context.Context, ttx domain.Tx) error {
+		if sendErr == nil {
+			return d.repo.MarkDelivered(ctx, ttx, dl.ID, now)
+		}
+
+		attempts := dl.Attempts + 1
+		dead := attempts >= d.cfg.MaxAttempts
+		next := now.Add(domainwebhook.Backoff(attempts, d.cfg.BackoffBase, d.cfg.BackoffMax))
+		if dead {
+			d.log.Warn("webhook delivery moved to dead letters", "delivery_id", dl.ID, "url", dl.URL, "err", sendErr)
+		}
+		return d.repo.MarkFailed(ctx, ttx, dl.ID, attempts, next, sendErr.Error(), dead)
+	})
+}
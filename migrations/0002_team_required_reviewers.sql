@@ -0,0 +1,14 @@
+-- +goose Up
+ALTER TABLE teams
+    ADD COLUMN IF NOT EXISTS required_reviewers SMALLINT NOT NULL DEFAULT 2
+    CHECK (required_reviewers BETWEEN 1 AND 10);
+
+ALTER TABLE pr_reviewers DROP CONSTRAINT IF EXISTS pr_reviewers_slot_check;
+ALTER TABLE pr_reviewers ADD CONSTRAINT pr_reviewers_slot_check CHECK (slot >= 1);
+
+-- +goose Down
+DELETE FROM pr_reviewers WHERE slot > 2;
+ALTER TABLE pr_reviewers DROP CONSTRAINT IF EXISTS pr_reviewers_slot_check;
+ALTER TABLE pr_reviewers ADD CONSTRAINT pr_reviewers_slot_check CHECK (slot IN (1, 2));
+
+ALTER TABLE teams DROP COLUMN IF EXISTS required_reviewers;
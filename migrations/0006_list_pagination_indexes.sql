@@ -0,0 +1,9 @@
+-- +goose Up
+CREATE INDEX IF NOT EXISTS idx_teams_created_id ON teams(created_at, id);
+CREATE INDEX IF NOT EXISTS idx_users_created_id ON users(created_at, id);
+CREATE INDEX IF NOT EXISTS idx_pr_created_id ON pull_requests(created_at, id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_pr_created_id;
+DROP INDEX IF EXISTS idx_users_created_id;
+DROP INDEX IF EXISTS idx_teams_created_id;
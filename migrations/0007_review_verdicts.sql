@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE pr_reviewers
+    ADD COLUMN verdict     TEXT NULL CHECK (verdict IN ('APPROVED', 'CHANGES_REQUESTED', 'COMMENTED')),
+    ADD COLUMN reviewed_at TIMESTAMPTZ NULL;
+
+-- +goose Down
+ALTER TABLE pr_reviewers
+    DROP COLUMN reviewed_at,
+    DROP COLUMN verdict;
@@ -0,0 +1,15 @@
+-- +goose Up
+ALTER TABLE users
+    ADD COLUMN max_open_reviews INTEGER NULL CHECK (max_open_reviews >= 0);
+
+ALTER TABLE teams
+    ADD COLUMN saturation_policy TEXT NOT NULL DEFAULT 'ASSIGN_ANYWAY'
+        CHECK (saturation_policy IN ('ASSIGN_ANYWAY', 'ASSIGN_FEWER', 'FAIL'));
+
+ALTER TABLE pr_reviewers
+    ADD COLUMN over_capacity BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose Down
+ALTER TABLE pr_reviewers DROP COLUMN over_capacity;
+ALTER TABLE teams DROP COLUMN saturation_policy;
+ALTER TABLE users DROP COLUMN max_open_reviews;
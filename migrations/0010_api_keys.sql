@@ -0,0 +1,13 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS api_keys (
+    id          TEXT PRIMARY KEY,
+    name        TEXT NOT NULL UNIQUE,
+    prefix      TEXT NOT NULL,
+    secret_hash TEXT NOT NULL UNIQUE,
+    scopes      TEXT[] NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    revoked_at  TIMESTAMPTZ NULL
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS api_keys;
@@ -0,0 +1,18 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS user_tags (
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    tag     TEXT NOT NULL,
+    PRIMARY KEY (user_id, tag)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_tags_tag ON user_tags(tag);
+
+CREATE TABLE IF NOT EXISTS pr_required_tags (
+    pr_id TEXT NOT NULL REFERENCES pull_requests(id) ON DELETE CASCADE,
+    tag   TEXT NOT NULL,
+    PRIMARY KEY (pr_id, tag)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS pr_required_tags;
+DROP TABLE IF EXISTS user_tags;
@@ -0,0 +1,9 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS team_rotation_cursors (
+    team_id         TEXT PRIMARY KEY REFERENCES teams(id) ON DELETE CASCADE,
+    last_created_at TIMESTAMPTZ NULL,
+    last_user_id    TEXT NULL
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS team_rotation_cursors;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE teams
+    ADD COLUMN required_reviewers SMALLINT NOT NULL DEFAULT 2
+    CHECK (required_reviewers BETWEEN 1 AND 10);
+
+-- +goose Down
+ALTER TABLE teams DROP COLUMN required_reviewers;
@@ -0,0 +1,17 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS pr_assignment_events (
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    pr_id       TEXT NOT NULL REFERENCES pull_requests(id) ON DELETE CASCADE,
+    event_type  TEXT NOT NULL,
+    slot        SMALLINT NULL,
+    old_user_id TEXT NULL,
+    new_user_id TEXT NULL,
+    actor       TEXT NOT NULL,
+    reason      TEXT NOT NULL,
+    created_at  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_pr_assignment_events_pr ON pr_assignment_events(pr_id, id);
+
+-- +goose Down
+DROP TABLE IF EXISTS pr_assignment_events;
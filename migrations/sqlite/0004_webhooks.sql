@@ -0,0 +1,44 @@
+-- +goose Up
+-- events holds a JSON array of event type names.
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id         TEXT PRIMARY KEY,
+    url        TEXT NOT NULL,
+    secret     TEXT NOT NULL,
+    events     TEXT NOT NULL CHECK (json_valid(events)),
+    is_active  BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS webhook_outbox (
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    event_type  TEXT NOT NULL,
+    payload     BLOB NOT NULL,
+    occurred_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    outbox_id       INTEGER NOT NULL REFERENCES webhook_outbox(id) ON DELETE CASCADE,
+    subscription_id TEXT NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+    status          TEXT NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'DELIVERED', 'DEAD')),
+    attempts        INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error      TEXT NULL,
+    delivered_at    TIMESTAMP NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status = 'PENDING';
+
+CREATE VIEW IF NOT EXISTS webhook_dead_letters AS
+SELECT d.id, d.subscription_id, s.url, o.id AS outbox_id, o.event_type, o.payload, o.occurred_at,
+       d.attempts, d.next_attempt_at, d.last_error
+FROM webhook_deliveries d
+JOIN webhook_outbox o ON o.id = d.outbox_id
+JOIN webhook_subscriptions s ON s.id = d.subscription_id
+WHERE d.status = 'DEAD';
+
+-- +goose Down
+DROP VIEW IF EXISTS webhook_dead_letters;
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_outbox;
+DROP TABLE IF EXISTS webhook_subscriptions;
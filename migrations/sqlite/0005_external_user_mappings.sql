@@ -0,0 +1,11 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS external_user_mappings (
+    provider       TEXT NOT NULL,
+    external_login TEXT NOT NULL,
+    user_id        TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (provider, external_login)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS external_user_mappings;
@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE pr_reviewers
+    ADD COLUMN verdict TEXT NULL CHECK (verdict IN ('APPROVED', 'CHANGES_REQUESTED', 'COMMENTED'));
+ALTER TABLE pr_reviewers
+    ADD COLUMN reviewed_at TIMESTAMP NULL;
+
+-- +goose Down
+ALTER TABLE pr_reviewers DROP COLUMN reviewed_at;
+ALTER TABLE pr_reviewers DROP COLUMN verdict;
@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS user_absences (
+    id                TEXT PRIMARY KEY,
+    user_id           TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    starts_at         TIMESTAMP NOT NULL,
+    ends_at           TIMESTAMP NOT NULL,
+    reason            TEXT NOT NULL DEFAULT '',
+    reassign_open_prs BOOLEAN NOT NULL DEFAULT FALSE,
+    reassigned_at     TIMESTAMP NULL,
+    created_at        TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_absences_user_period ON user_absences(user_id, starts_at, ends_at);
+CREATE INDEX IF NOT EXISTS idx_user_absences_reassign_due ON user_absences(starts_at)
+    WHERE reassign_open_prs AND reassigned_at IS NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS user_absences;
@@ -0,0 +1,14 @@
+-- +goose Up
+-- scopes holds a JSON array of scope names.
+CREATE TABLE IF NOT EXISTS api_keys (
+    id          TEXT PRIMARY KEY,
+    name        TEXT NOT NULL UNIQUE,
+    prefix      TEXT NOT NULL,
+    secret_hash TEXT NOT NULL UNIQUE,
+    scopes      TEXT NOT NULL CHECK (json_valid(scopes)),
+    created_at  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    revoked_at  TIMESTAMP NULL
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS api_keys;
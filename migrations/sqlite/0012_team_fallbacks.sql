@@ -0,0 +1,18 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS team_fallbacks (
+    team_id          TEXT NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    position         SMALLINT NOT NULL,
+    fallback_team_id TEXT NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    PRIMARY KEY (team_id, position),
+    UNIQUE (team_id, fallback_team_id),
+    CHECK (fallback_team_id <> team_id)
+);
+
+-- fallback_team names the team a reviewer was borrowed from; NULL for
+-- reviewers from the author's own team.
+ALTER TABLE pr_reviewers
+    ADD COLUMN fallback_team TEXT NULL;
+
+-- +goose Down
+ALTER TABLE pr_reviewers DROP COLUMN fallback_team;
+DROP TABLE IF EXISTS team_fallbacks;
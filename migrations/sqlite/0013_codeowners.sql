@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS team_codeowners (
+    team_id    TEXT PRIMARY KEY REFERENCES teams(id) ON DELETE CASCADE,
+    source     TEXT NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS pr_changed_files (
+    pr_id TEXT NOT NULL REFERENCES pull_requests(id) ON DELETE CASCADE,
+    path  TEXT NOT NULL,
+    PRIMARY KEY (pr_id, path)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS pr_changed_files;
+DROP TABLE IF EXISTS team_codeowners;